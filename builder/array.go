@@ -0,0 +1,99 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// ArrayLength defines how a list is converted into an array with a different length.
+type ArrayLength byte
+
+const (
+	// ArrayLengthError returns an error, if the lengths differ.
+	ArrayLengthError ArrayLength = iota
+	// ArrayLengthTruncate copies as many elements as fit into the array.
+	ArrayLengthTruncate
+)
+
+// Array handles fixed size array target types.
+type Array struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*Array) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return source.List && target.ListFixed && kind == xtype.InSourceOutTarget
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*Array) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	// arrays of basic types are values and can be copied with an assignment
+	if source.ListFixed && source.ListInner.Basic && types.ConvertibleTo(source.T, target.T) {
+		if types.Identical(source.T, target.T) {
+			return nil, sourceID, nil
+		}
+		return nil, xtype.OtherID(target.TypeAsJen().Call(sourceID.Code.Clone())), nil
+	}
+
+	var (
+		targetArray = ctx.Name(target.ID())
+		truncate    = ctx.ArrayLength == ArrayLengthTruncate
+		stmt        = []jen.Code{
+			jen.Var().Id(targetArray).Add(target.TypeAsJen()),
+		}
+	)
+
+	switch {
+	case truncate:
+	case source.ListFixed:
+		if source.ListLen != target.ListLen {
+			return nil, nil, NewError(arrayLengthError(source.T.String(), target.T.String()))
+		}
+	default:
+		errID := jen.Qual("fmt", "Errorf").Call(
+			jen.Lit(fmt.Sprintf("expected %d elements for %s but got %%d", target.ListLen, target.T)),
+			jen.Len(sourceID.Code.Clone()),
+		)
+		ret, err := gen.ReturnError(ctx, "the length check of "+target.T.String(), errID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		stmt = append(stmt, jen.If(jen.Len(sourceID.Code.Clone()).Op("!=").Lit(int(target.ListLen))).Block(ret...))
+	}
+
+	if source.ListInner.Basic && types.Identical(source.ListInner.T, target.ListInner.T) && (!source.ListFixed || sourceID.Variable) {
+		sourceSlice := sourceID.Code.Clone()
+		if source.ListFixed {
+			sourceSlice = sourceSlice.Index(jen.Op(":"))
+		}
+
+		stmt = append(stmt, jen.Copy(jen.Id(targetArray).Index(jen.Op(":")), sourceSlice))
+		return stmt, xtype.VariableID(jen.Id(targetArray)), nil
+	}
+
+	index := ctx.Index()
+	newStmt, err := buildListElement(gen, ctx, sourceID, source, target, targetArray, index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cond := jen.Id(index).Op("<").Len(sourceID.Code.Clone())
+	if truncate {
+		cond = cond.Op("&&").Id(index).Op("<").Len(jen.Id(targetArray))
+	}
+	stmt = append(stmt, jen.For(jen.Id(index).Op(":=").Lit(0), cond, jen.Id(index).Op("++")).Block(newStmt...))
+
+	return stmt, xtype.VariableID(jen.Id(targetArray)), nil
+}
+
+func arrayLengthError(source, target string) string {
+	return fmt.Sprintf(`Cannot convert %s to %s because the lengths differ.
+
+Copy as many elements as fit into the array with:
+
+    goverter:arrayLength truncate
+
+See https://github.com/pengdaCN/goverter/blob/main/addition.md`, source, target)
+}
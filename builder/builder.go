@@ -1,6 +1,10 @@
 package builder
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/dave/jennifer/jen"
 	"github.com/pengdaCN/goverter/namer"
 	"github.com/pengdaCN/goverter/xtype"
@@ -14,6 +18,13 @@ type Builder interface {
 	Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error)
 }
 
+// ContextMatcher can be implemented by a Builder, that additionally depends on the settings of the MethodContext.
+type ContextMatcher interface {
+	// MatchesContext returns true, if the builder can handle the given types with the settings of ctx. It's only
+	// called, if Builder#Matches returned true.
+	MatchesContext(ctx *MethodContext, source, target *xtype.Type) bool
+}
+
 // Generator checks all existing builders if they can create a conversion implementations for the given source and target type
 // If no one Builder#Matches then, an error is returned.
 type Generator interface {
@@ -24,6 +35,10 @@ type Generator interface {
 		id *xtype.JenID,
 		err *Error,
 	)
+	// ReturnError marks the current method as returning an error and creates the
+	// statement returning the given error. origin is used in the message, if the current
+	// method was explicitly declared without an error.
+	ReturnError(ctx *MethodContext, origin string, errID *jen.Statement) ([]jen.Code, *Error)
 	Name() string
 }
 
@@ -35,15 +50,68 @@ type MethodContext struct {
 	IdentityMapping  map[string]struct{}
 	GlobalExtend     map[xtype.Signature]*MethodDefinition
 	MethodExtend     map[xtype.Signature]*MethodDefinition
+	Implementations  map[string]*Implementations
+	Optionals        map[string]*Optional
 	SearchTag        []string
 	Signature        xtype.Signature
 	TargetType       *xtype.Type
 	WantMethodKind   xtype.MethodKind
+	ArrayLength      ArrayLength
+	NumericNarrowing NumericNarrowing
+	CopyBytes        bool
+	NilPolicy        NilPolicy
+	CopySameType     CopySameType
+	NilCollections   NilCollections
+	Enum             bool
+	EnumPolicy       EnumPolicy
+	EnumMapping      map[string]string
+	EnumTrimPrefix   string
+	MapKeys          map[string]*MapKey
 	MatchIgnoreCase  bool
 	NoStrict         bool
 	IgnoreUnexported bool
 	TargetID         *xtype.JenID
-	ID               string
+	// TargetField is the name of the struct field currently converted.
+	TargetField string
+	// FieldPath is the path of the source field currently converted, starting at the source of the current method.
+	FieldPath string
+	ID        string
+}
+
+// Settings returns the directives of the context, that change the generated conversions. Generated methods are only
+// reused by methods with the same settings.
+func (m *MethodContext) Settings() string {
+	return fmt.Sprintf("nilPolicy=%d numericNarrowing=%d copySameType=%d nilCollections=%d enum=%t enumPolicy=%d enumMapping=%s arrayLength=%d mapKeys=%s copyBytes=%t enumTrimPrefix=%q",
+		m.NilPolicy, m.NumericNarrowing, m.CopySameType, m.NilCollections, m.Enum, m.EnumPolicy, formatMapping(m.EnumMapping), m.ArrayLength,
+		formatMapKeys(m.MapKeys), m.CopyBytes, m.EnumTrimPrefix)
+}
+
+// formatMapKeys returns the sorted goverter:mapKey declarations of the target fields.
+func formatMapKeys(mapKeys map[string]*MapKey) string {
+	mapping := make(map[string]string, len(mapKeys))
+	for field, mapKey := range mapKeys {
+		mapping[field] = fmt.Sprintf("%s/%t", mapKey.Field, mapKey.Sorted)
+	}
+	return formatMapping(mapping)
+}
+
+// formatMapping returns the sorted key value pairs of mapping.
+func formatMapping(mapping map[string]string) string {
+	pairs := make([]string, 0, len(mapping))
+	for key, value := range mapping {
+		pairs = append(pairs, key+":"+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// enterElement appends the element marker to FieldPath, the returned func restores it.
+func (m *MethodContext) enterElement() func() {
+	fieldPath := m.FieldPath
+	m.FieldPath += "[]"
+	return func() {
+		m.FieldPath = fieldPath
+	}
 }
 
 func (m *MethodContext) Enter() *MethodContext {
@@ -54,9 +122,23 @@ func (m *MethodContext) Enter() *MethodContext {
 		IdentityMapping:  m.IdentityMapping,
 		GlobalExtend:     m.GlobalExtend,
 		MethodExtend:     m.MethodExtend,
+		Implementations:  m.Implementations,
+		Optionals:        m.Optionals,
 		MatchIgnoreCase:  m.MatchIgnoreCase,
 		NoStrict:         m.NoStrict,
 		IgnoreUnexported: m.IgnoreUnexported,
+		ArrayLength:      m.ArrayLength,
+		NumericNarrowing: m.NumericNarrowing,
+		CopyBytes:        m.CopyBytes,
+		NilPolicy:        m.NilPolicy,
+		CopySameType:     m.CopySameType,
+		NilCollections:   m.NilCollections,
+		Enum:             m.Enum,
+		EnumPolicy:       m.EnumPolicy,
+		EnumMapping:      m.EnumMapping,
+		EnumTrimPrefix:   m.EnumTrimPrefix,
+		MapKeys:          m.MapKeys,
+		FieldPath:        m.FieldPath,
 		ID:               m.ID,
 		SearchTag:        m.SearchTag,
 	}
@@ -70,9 +152,23 @@ func (m *MethodContext) EnterWithNamer() *MethodContext {
 		IdentityMapping:  m.IdentityMapping,
 		GlobalExtend:     m.GlobalExtend,
 		MethodExtend:     m.MethodExtend,
+		Implementations:  m.Implementations,
+		Optionals:        m.Optionals,
 		MatchIgnoreCase:  m.MatchIgnoreCase,
 		NoStrict:         m.NoStrict,
 		IgnoreUnexported: m.IgnoreUnexported,
+		ArrayLength:      m.ArrayLength,
+		NumericNarrowing: m.NumericNarrowing,
+		CopyBytes:        m.CopyBytes,
+		NilPolicy:        m.NilPolicy,
+		CopySameType:     m.CopySameType,
+		NilCollections:   m.NilCollections,
+		Enum:             m.Enum,
+		EnumPolicy:       m.EnumPolicy,
+		EnumMapping:      m.EnumMapping,
+		EnumTrimPrefix:   m.EnumTrimPrefix,
+		MapKeys:          m.MapKeys,
+		FieldPath:        m.FieldPath,
 		ID:               m.ID,
 		SearchTag:        m.SearchTag,
 	}
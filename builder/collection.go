@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// NilCollections defines how a nil slice or map is converted.
+type NilCollections byte
+
+const (
+	// NilCollectionsEmpty converts a nil slice or map into an empty one.
+	NilCollectionsEmpty NilCollections = iota
+	// NilCollectionsPreserve converts a nil slice or map into nil.
+	NilCollectionsPreserve
+)
+
+// buildCollection declares the slice or map name initialized with create and filled with fill. With
+// NilCollectionsPreserve name stays nil, if the source is nil.
+func buildCollection(ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type, name string, create *jen.Statement, fill ...jen.Code) []jen.Code {
+	// arrays can't be nil
+	if ctx.NilCollections != NilCollectionsPreserve || source.ListFixed {
+		return append([]jen.Code{jen.Id(name).Op(":=").Add(create)}, fill...)
+	}
+
+	block := append([]jen.Code{jen.Id(name).Op("=").Add(create)}, fill...)
+	return []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		jen.If(sourceID.Code.Clone().Op("!=").Nil()).Block(block...),
+	}
+}
+
+// collectionTarget returns the collection the method converts into, for in-place methods it's the collection the
+// target points to.
+func collectionTarget(target *xtype.Type, kind xtype.MethodKind) (*xtype.Type, bool) {
+	switch kind {
+	case xtype.InSourceOutTarget:
+		return target, true
+	case xtype.InSourceIn2Target:
+		if target.Pointer {
+			return target.PointerInner, true
+		}
+	}
+	return nil, false
+}
+
+// buildCollectionInto returns early for a nil target and fills the collection the target points to. With
+// NilCollectionsPreserve a nil source runs preserve instead of fill.
+func buildCollectionInto(ctx *MethodContext, sourceID, targetID *xtype.JenID, source *xtype.Type, preserve []jen.Code, fill ...jen.Code) []jen.Code {
+	stmt := []jen.Code{jen.If(targetID.Code.Clone().Op("==").Nil()).Block(jen.Return())}
+	// arrays can't be nil
+	if ctx.NilCollections != NilCollectionsPreserve || source.ListFixed {
+		return append(stmt, fill...)
+	}
+
+	if len(preserve) == 0 {
+		return append(stmt, jen.If(sourceID.Code.Clone().Op("!=").Nil()).Block(fill...))
+	}
+	return append(stmt, jen.If(sourceID.Code.Clone().Op("==").Nil()).Block(preserve...).Else().Block(fill...))
+}
@@ -0,0 +1,145 @@
+package builder
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// Convertible handles types that can be converted with a Go conversion, f.ex. structs differing only in
+// their tags or named types sharing the underlying type. Types containing slices, maps or pointers are only converted
+// with goverter:copySameType shallow, because the target would share them with the source.
+type Convertible struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*Convertible) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return kind == xtype.InSourceOutTarget && convertibleTypes(source, target)
+}
+
+// MatchesContext returns false, if the settings of ctx could change the result of the conversion.
+func (*Convertible) MatchesContext(ctx *MethodContext, source, target *xtype.Type) bool {
+	if ctx.CopySameType != CopySameTypeShallow && hasReferences(source.T) {
+		return false
+	}
+	return directContext(ctx, source, target)
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*Convertible) Build(_ Generator, _ *MethodContext, sourceID *xtype.JenID, _, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	return nil, xtype.OtherID(target.TypeAsJen().Call(sourceID.Code.Clone())), nil
+}
+
+// convertible returns true, if Convertible handles the conversion from source to target.
+func convertible(ctx *MethodContext, source, target *xtype.Type) bool {
+	c := Convertible{}
+	return c.Matches(source, target, xtype.InSourceOutTarget) && c.MatchesContext(ctx, source, target)
+}
+
+func convertibleTypes(source, target *xtype.Type) bool {
+	// pointers would share the value, basic types are converted by Basic and Numeric
+	if source.Pointer || target.Pointer || source.Interface || target.Interface || source.TypeParam || target.TypeParam {
+		return false
+	}
+	if _, ok := source.T.Underlying().(*types.Basic); ok {
+		return false
+	}
+	// identical types are copied
+	if types.Identical(source.T, target.T) {
+		return false
+	}
+	return types.IdenticalIgnoreTags(source.T.Underlying(), target.T.Underlying())
+}
+
+// directContext returns false, if the settings of ctx could change the result of converting source directly.
+func directContext(ctx *MethodContext, source, target *xtype.Type) bool {
+	if ctx.optional(source) != nil || ctx.optional(target) != nil {
+		return false
+	}
+	if _, ok := sqlNullValue(source); ok {
+		return false
+	}
+	if _, ok := sqlNullValue(target); ok {
+		return false
+	}
+
+	var (
+		nested    = map[string]struct{}{}
+		hasStruct bool
+		hasBytes  bool
+	)
+	walkTypes(source.T, nested, func(t types.Type) {
+		switch u := t.Underlying().(type) {
+		case *types.Struct:
+			hasStruct = true
+		case *types.Slice:
+			if basic, ok := u.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+				hasBytes = true
+			}
+		}
+	})
+
+	if hasStruct {
+		// the field settings apply to all structs converted in the method
+		if len(ctx.Mapping) != 0 || len(ctx.IgnoredFields) != 0 || len(ctx.IdentityMapping) != 0 {
+			return false
+		}
+		// the fields could be matched by their tags
+		if len(ctx.SearchTag) != 0 && !types.Identical(source.T.Underlying(), target.T.Underlying()) {
+			return false
+		}
+	}
+	if hasBytes && ctx.CopyBytes {
+		return false
+	}
+	// an extend method would convert the nested type differently
+	for _, extend := range []map[xtype.Signature]*MethodDefinition{ctx.MethodExtend, ctx.GlobalExtend} {
+		for signature := range extend {
+			if _, ok := nested[signature.Source]; ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// hasReferences returns true, if t contains slices, maps or pointers.
+func hasReferences(t types.Type) bool {
+	var references bool
+	walkTypes(t, map[string]struct{}{}, func(t types.Type) {
+		switch t.Underlying().(type) {
+		case *types.Slice, *types.Map, *types.Pointer:
+			references = true
+		}
+	})
+	return references
+}
+
+// walkTypes calls visit for t and all types contained in t.
+func walkTypes(t types.Type, seen map[string]struct{}, visit func(t types.Type)) {
+	key := xtype.TypeString(t)
+	if _, ok := seen[key]; ok {
+		return
+	}
+	seen[key] = struct{}{}
+	visit(t)
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		walkTypes(u.Elem(), seen, visit)
+	case *types.Slice:
+		walkTypes(u.Elem(), seen, visit)
+	case *types.Array:
+		walkTypes(u.Elem(), seen, visit)
+	case *types.Chan:
+		walkTypes(u.Elem(), seen, visit)
+	case *types.Map:
+		walkTypes(u.Key(), seen, visit)
+		walkTypes(u.Elem(), seen, visit)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			walkTypes(u.Field(i).Type(), seen, visit)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package builder
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// EnumPolicy defines how enum values without a counterpart in the target enum are handled.
+type EnumPolicy byte
+
+const (
+	// EnumPolicyNone fails the generation if a declared source constant has no counterpart and returns an
+	// error on undeclared values.
+	EnumPolicyNone EnumPolicy = iota
+	// EnumPolicyZero uses the zero value of the target.
+	EnumPolicyZero
+	// EnumPolicyError returns an error.
+	EnumPolicyError
+)
+
+// Enum handles conversions between named basic types with declared constants.
+type Enum struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*Enum) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return kind == xtype.InSourceOutTarget &&
+		source.Named && source.Basic && target.Named && target.Basic &&
+		!types.Identical(source.T, target.T) &&
+		len(enumConstants(source)) != 0 && len(enumConstants(target)) != 0
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*Enum) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if !ctx.Enum {
+		// keep the plain conversions of named basic types
+		for _, rule := range []Builder{&Basic{}, &Numeric{}} {
+			if rule.Matches(source, target, xtype.InSourceOutTarget) {
+				return rule.Build(gen, ctx, sourceID, source, target)
+			}
+		}
+		return nil, nil, NewError(fmt.Sprintf(`TypeMismatch: Cannot convert %s to %s
+
+Map the enum constants by name with:
+
+    goverter:enum
+
+See https://github.com/pengdaCN/goverter/blob/main/addition.md`, source.T, target.T))
+	}
+
+	targetConsts := enumConstants(target)
+	targetByName := make(map[string]*types.Const, len(targetConsts))
+	for _, c := range targetConsts {
+		targetByName[c.Name()] = c
+	}
+
+	var (
+		name      = ctx.Name(target.ID())
+		cases     []jen.Code
+		unmatched []string
+		seen      []constant.Value
+	)
+	for _, c := range enumConstants(source) {
+		if containsConstant(seen, c.Val()) {
+			// constants with the same value would be duplicate cases
+			continue
+		}
+		seen = append(seen, c.Val())
+
+		tc, ok := matchEnumConstant(ctx, c, source, target, targetByName)
+		if !ok {
+			unmatched = append(unmatched, c.Name())
+			continue
+		}
+		cases = append(cases, jen.Case(jen.Qual(c.Pkg().Path(), c.Name())).Block(
+			jen.Id(name).Op("=").Qual(tc.Pkg().Path(), tc.Name()),
+		))
+	}
+
+	var def []jen.Code
+	switch ctx.EnumPolicy {
+	case EnumPolicyNone:
+		if len(unmatched) != 0 {
+			return nil, nil, NewError(enumUnmatchedError(source.T.String(), target.T.String(), unmatched))
+		}
+		// values without a declared constant can only be reported as error, goverter:enum zero must be used
+		// if the method returns no error
+		origin := fmt.Sprintf("the enum conversion from %s to %s for values without a declared constant (or use goverter:enum zero)", source.T, target.T)
+		ret, err := gen.ReturnError(ctx, origin, enumValueError(sourceID, source, target))
+		if err != nil {
+			return nil, nil, err
+		}
+		def = ret
+	case EnumPolicyError:
+		ret, err := gen.ReturnError(ctx, fmt.Sprintf("the enum conversion from %s to %s", source.T, target.T), enumValueError(sourceID, source, target))
+		if err != nil {
+			return nil, nil, err
+		}
+		def = ret
+	}
+	if def != nil {
+		cases = append(cases, jen.Default().Block(def...))
+	}
+
+	stmt := []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		jen.Switch(sourceID.Code.Clone()).Block(cases...),
+	}
+
+	return stmt, xtype.OtherID(jen.Id(name)), nil
+}
+
+// enumConstants returns the exported constants declared with the named type t in declaration order.
+func enumConstants(t *xtype.Type) []*types.Const {
+	obj := t.NamedType.Obj()
+	if obj.Pkg() == nil {
+		return nil
+	}
+
+	scope := obj.Pkg().Scope()
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && c.Exported() && types.Identical(c.Type(), t.T) {
+			consts = append(consts, c)
+		}
+	}
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+	return consts
+}
+
+// matchEnumConstant finds the target constant for c, either by goverter:enumMap, the same name or the same
+// name without the type name as prefix.
+func matchEnumConstant(ctx *MethodContext, c *types.Const, source, target *xtype.Type, targetByName map[string]*types.Const) (*types.Const, bool) {
+	if mapped, ok := ctx.EnumMapping[c.Name()]; ok {
+		tc, ok := targetByName[mapped]
+		return tc, ok
+	}
+
+	if tc, ok := targetByName[c.Name()]; ok {
+		return tc, true
+	}
+
+	sourcePrefix := source.NamedType.Obj().Name()
+	targetPrefix := target.NamedType.Obj().Name()
+	if !strings.HasPrefix(c.Name(), sourcePrefix) {
+		return nil, false
+	}
+	tc, ok := targetByName[targetPrefix+strings.TrimPrefix(c.Name(), sourcePrefix)]
+	return tc, ok
+}
+
+func containsConstant(values []constant.Value, v constant.Value) bool {
+	for _, value := range values {
+		if constant.Compare(value, token.EQL, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumUnmatchedError(source, target string, unmatched []string) string {
+	return fmt.Sprintf(`Cannot convert enum %s to %s because the constants %s have no counterpart.
+
+Map the constants explicitly with:
+
+    goverter:enumMap SourceConstant TargetConstant
+
+Or handle them at runtime with:
+
+    goverter:enum zero
+    goverter:enum error
+
+See https://github.com/pengdaCN/goverter/blob/main/addition.md`, source, target, strings.Join(unmatched, ", "))
+}
+
+func enumValueError(sourceID *xtype.JenID, source, target *xtype.Type) *jen.Statement {
+	return jen.Qual("fmt", "Errorf").Call(
+		jen.Lit(fmt.Sprintf("cannot convert enum value %%v of %s to %s", source.T, target.T)),
+		sourceID.Code.Clone(),
+	)
+}
+
+// EnumString handles conversions between enums with a non string basic type and strings.
+type EnumString struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*EnumString) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	if kind != xtype.InSourceOutTarget {
+		return false
+	}
+
+	return (isEnum(source) && isPlainString(target)) || (isPlainString(source) && isEnum(target))
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*EnumString) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if isEnum(source) {
+		return buildEnumToString(ctx, sourceID, source, target)
+	}
+	return buildStringToEnum(gen, ctx, sourceID, source, target)
+}
+
+func buildEnumToString(ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if hasStringMethod(source) {
+		call := sourceID.Code.Clone().Dot("String").Call()
+		if target.Named {
+			call = target.TypeAsJen().Call(call)
+		}
+		return nil, xtype.OtherID(call), nil
+	}
+
+	var (
+		name  = ctx.Name(target.ID())
+		cases []jen.Code
+		seen  []constant.Value
+	)
+	for _, c := range enumConstants(source) {
+		if containsConstant(seen, c.Val()) {
+			continue
+		}
+		seen = append(seen, c.Val())
+
+		cases = append(cases, jen.Case(jen.Qual(c.Pkg().Path(), c.Name())).Block(
+			jen.Id(name).Op("=").Lit(enumConstantName(ctx, c)),
+		))
+	}
+	// undeclared values are formatted like the stringer tool does
+	undeclared := jen.Qual("fmt", "Sprintf").Call(jen.Lit(source.NamedType.Obj().Name()+"(%v)"), sourceID.Code.Clone())
+	if target.Named {
+		undeclared = target.TypeAsJen().Call(undeclared)
+	}
+	cases = append(cases, jen.Default().Block(jen.Id(name).Op("=").Add(undeclared)))
+
+	stmt := []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		jen.Switch(sourceID.Code.Clone()).Block(cases...),
+	}
+	return stmt, xtype.OtherID(jen.Id(name)), nil
+}
+
+func buildStringToEnum(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	var (
+		name      = ctx.Name(target.ID())
+		cases     []jen.Code
+		seen      []constant.Value
+		useString = hasStringMethod(target)
+	)
+	for _, c := range enumConstants(target) {
+		constID := jen.Qual(c.Pkg().Path(), c.Name())
+
+		var value *jen.Statement
+		if useString {
+			if containsConstant(seen, c.Val()) {
+				continue
+			}
+			seen = append(seen, c.Val())
+			value = constID.Clone().Dot("String").Call()
+			if source.Named {
+				value = source.TypeAsJen().Call(value)
+			}
+		} else {
+			value = jen.Lit(enumConstantName(ctx, c))
+		}
+
+		cases = append(cases, jen.Case(value).Block(jen.Id(name).Op("=").Add(constID)))
+	}
+
+	errID := jen.Qual("fmt", "Errorf").Call(
+		jen.Lit(fmt.Sprintf("unknown %s value %%q", target.T)),
+		sourceID.Code.Clone(),
+	)
+	ret, err := gen.ReturnError(ctx, fmt.Sprintf("the conversion from %s to the enum %s", source.T, target.T), errID)
+	if err != nil {
+		return nil, nil, err
+	}
+	cases = append(cases, jen.Default().Block(ret...))
+
+	stmt := []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		jen.Switch(sourceID.Code.Clone()).Block(cases...),
+	}
+	return stmt, xtype.OtherID(jen.Id(name)), nil
+}
+
+// enumConstantName returns the string representation of c, without goverter:enumTrimPrefix.
+func enumConstantName(ctx *MethodContext, c *types.Const) string {
+	return strings.TrimPrefix(c.Name(), ctx.EnumTrimPrefix)
+}
+
+func isEnum(t *xtype.Type) bool {
+	return t.Named && t.Basic && t.BasicType.Info()&types.IsString == 0 && len(enumConstants(t)) != 0
+}
+
+func isPlainString(t *xtype.Type) bool {
+	return isString(t) && (!t.Named || len(enumConstants(t)) == 0)
+}
+
+func hasStringMethod(t *xtype.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t.T, false, nil, "String")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	return types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+}
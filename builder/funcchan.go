@@ -0,0 +1,29 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// FuncChan handles func and chan types, their values can only be assigned.
+type FuncChan struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*FuncChan) Matches(source, target *xtype.Type, _ xtype.MethodKind) bool {
+	return (source.Func && target.Func) || (source.Chan && target.Chan)
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*FuncChan) Build(_ Generator, _ *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if types.AssignableTo(source.T, target.T) {
+		return nil, sourceID, nil
+	}
+	if types.ConvertibleTo(source.T, target.T) {
+		return nil, xtype.OtherID(target.TypeAsJen().Call(sourceID.Code)), nil
+	}
+
+	return nil, nil, NewError(fmt.Sprintf("TypeMismatch: Cannot convert %s to %s\n\nfunc and chan types can only be assigned, if their signature or element type is identical.", source.T, target.T))
+}
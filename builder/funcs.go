@@ -2,11 +2,28 @@ package builder
 
 import "github.com/pengdaCN/goverter/xtype"
 
-func optimizeZeroCopy(source *xtype.Type, target *xtype.Type) (
+func optimizeZeroCopy(ctx *MethodContext, source *xtype.Type, target *xtype.Type) (
 	nextSource *xtype.Type,
 	nextTarget *xtype.Type,
 	zeroCopy bool,
 ) {
+	// the sql Null types are converted by SQLNull
+	if _, ok := sqlNullValue(source); ok {
+		return
+	}
+	if _, ok := sqlNullValue(target); ok {
+		return
+	}
+	// the optional types are converted by OptionalWrapper
+	if ctx.optional(source) != nil || ctx.optional(target) != nil {
+		return
+	}
+
+	// identical and convertible types are converted by SameType and Convertible
+	if sameType(ctx, source, target) || convertible(ctx, deref(source), deref(target)) {
+		return
+	}
+
 	for origin, next := range map[*xtype.Type]**xtype.Type{
 		source: &nextSource,
 		target: &nextTarget,
@@ -25,3 +42,11 @@ func optimizeZeroCopy(source *xtype.Type, target *xtype.Type) (
 
 	return
 }
+
+// deref returns the type t points to, or t if it isn't a pointer.
+func deref(t *xtype.Type) *xtype.Type {
+	if t.Pointer {
+		return t.PointerInner
+	}
+	return t
+}
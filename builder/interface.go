@@ -0,0 +1,38 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// Interface handles interface target types.
+type Interface struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*Interface) Matches(_, target *xtype.Type, kind xtype.MethodKind) bool {
+	return target.Interface && kind == xtype.InSourceOutTarget
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*Interface) Build(_ Generator, _ *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if !types.AssignableTo(source.T, target.T) {
+		return nil, nil, NewError(notImplementedError(source.T, target))
+	}
+
+	return nil, sourceID, nil
+}
+
+func notImplementedError(source types.Type, target *xtype.Type) string {
+	cause := fmt.Sprintf("TypeMismatch: %s does not implement %s", source, target.T)
+	if method, wrongType := types.MissingMethod(source, target.InterfaceType, true); method != nil {
+		if wrongType {
+			return fmt.Sprintf("%s (wrong type for method %s)", cause, method.Name())
+		}
+		return fmt.Sprintf("%s (missing method %s)", cause, method.Name())
+	}
+
+	return cause
+}
@@ -5,23 +5,80 @@ import (
 	"github.com/pengdaCN/goverter/xtype"
 )
 
-// List handles array / slice types.
+// List handles array / slice types. In-place methods convert into the slice the target points to and reuse its
+// capacity.
 type List struct{}
 
 // Matches returns true, if the builder can create handle the given types.
 func (*List) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
-	return source.List && target.List && !target.ListFixed && kind == xtype.InSourceOutTarget
+	inner, ok := collectionTarget(target, kind)
+	return ok && source.List && inner.List && !inner.ListFixed
 }
 
 // Build creates conversion source code for the given source and target type.
 func (*List) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if ctx.WantMethodKind == xtype.InSourceIn2Target {
+		return buildListInto(gen, ctx, sourceID, source, target)
+	}
+
 	var (
-		targetSlice                             = ctx.Name(target.ID())
-		index                                   = ctx.Index()
-		nextSource, nextTarget, enabledZeroCopy = optimizeZeroCopy(source.ListInner, target.ListInner)
+		targetSlice = ctx.Name(target.ID())
+		index       = ctx.Index()
+	)
+
+	newStmt, err := buildListElement(gen, ctx, sourceID, source, target, targetSlice, index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt := buildCollection(ctx, sourceID, source, target, targetSlice,
+		jen.Make(target.TypeAsJen(), jen.Len(sourceID.Code.Clone())),
+		jen.For(jen.Id(index).Op(":=").Lit(0), jen.Id(index).Op("<").Len(sourceID.Code.Clone()), jen.Id(index).Op("++")).
+			Block(newStmt...),
+	)
+
+	return stmt, xtype.VariableID(jen.Id(targetSlice)), nil
+}
+
+// buildListInto converts the source into the slice the target points to. The slice is only reallocated, if its
+// capacity is too small.
+func buildListInto(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	var (
+		targetID    = ctx.TargetID
+		inner       = target.PointerInner
+		targetSlice = ctx.Name(inner.ID())
+		index       = ctx.Index()
+	)
+
+	ctx.WantMethodKind = xtype.InSourceOutTarget
+	newStmt, err := buildListElement(gen, ctx, sourceID, source, inner, targetSlice, index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt := buildCollectionInto(ctx, sourceID, targetID, source,
+		[]jen.Code{jen.Op("*").Add(targetID.Code.Clone()).Op("=").Nil()},
+		jen.Id(targetSlice).Op(":=").Op("*").Add(targetID.Code.Clone()),
+		jen.If(jen.Id(targetSlice).Op("==").Nil().Op("||").Cap(jen.Id(targetSlice)).Op("<").Len(sourceID.Code.Clone())).
+			Block(jen.Id(targetSlice).Op("=").Make(inner.TypeAsJen(), jen.Len(sourceID.Code.Clone()))),
+		jen.Id(targetSlice).Op("=").Id(targetSlice).Index(jen.Empty(), jen.Len(sourceID.Code.Clone())),
+		jen.For(jen.Id(index).Op(":=").Lit(0), jen.Id(index).Op("<").Len(sourceID.Code.Clone()), jen.Id(index).Op("++")).
+			Block(newStmt...),
+		jen.Op("*").Add(targetID.Code.Clone()).Op("=").Id(targetSlice),
+	)
+
+	return stmt, nil, nil
+}
+
+// buildListElement creates the statements converting the element at index of the source into targetList.
+func buildListElement(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type, targetList, index string) ([]jen.Code, *Error) {
+	defer ctx.enterElement()()
+
+	var (
+		nextSource, nextTarget, enabledZeroCopy = optimizeZeroCopy(ctx, source.ListInner, target.ListInner)
 		nextSourceID                            = xtype.VariableID(sourceID.Code.Clone().Index(jen.Id(index)))
 	)
-	ctx.TargetID = xtype.OtherID(jen.Id(targetSlice).Index(jen.Id(index)))
+	ctx.TargetID = xtype.OtherID(jen.Id(targetList).Index(jen.Id(index)))
 	if enabledZeroCopy {
 		ctx.WantMethodKind = xtype.InSourceIn2Target
 
@@ -39,7 +96,7 @@ func (*List) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, sou
 
 	newStmt, newID, err := gen.Build(ctx, nextSourceID, nextSource, nextTarget)
 	if err != nil {
-		return nil, nil, err.Lift(&Path{
+		return nil, err.Lift(&Path{
 			SourceID:   "[]",
 			SourceType: source.ListInner.T.String(),
 			TargetID:   "[]",
@@ -50,20 +107,14 @@ func (*List) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, sou
 	if enabledZeroCopy {
 		if target.ListInner.Pointer {
 			_newStmt := make([]jen.Code, len(newStmt)+1)
-			_newStmt[0] = jen.Id(targetSlice).Index(jen.Id(index)).Op("=").Add(jen.New(target.ListInner.PointerInner.TypeAsJen()))
+			_newStmt[0] = jen.Id(targetList).Index(jen.Id(index)).Op("=").Add(jen.New(target.ListInner.PointerInner.TypeAsJen()))
 			copy(_newStmt[1:], newStmt)
 
 			newStmt = _newStmt
 		}
 	} else {
-		newStmt = append(newStmt, jen.Id(targetSlice).Index(jen.Id(index)).Op("=").Add(newID.Code))
-	}
-
-	stmt := []jen.Code{
-		jen.Id(targetSlice).Op(":=").Make(target.TypeAsJen(), jen.Len(sourceID.Code.Clone())),
-		jen.For(jen.Id(index).Op(":=").Lit(0), jen.Id(index).Op("<").Len(sourceID.Code.Clone()), jen.Id(index).Op("++")).
-			Block(newStmt...),
+		newStmt = append(newStmt, jen.Id(targetList).Index(jen.Id(index)).Op("=").Add(newID.Code))
 	}
 
-	return stmt, xtype.VariableID(jen.Id(targetSlice)), nil
+	return newStmt, nil
 }
@@ -5,31 +5,79 @@ import (
 	"github.com/pengdaCN/goverter/xtype"
 )
 
-// Map handles map types.
+// Map handles map types. In-place methods merge the entries into the map the target points to.
 type Map struct{}
 
 // Matches returns true, if the builder can create handle the given types.
 func (*Map) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
-	return source.Map && target.Map && kind == xtype.InSourceOutTarget
+	inner, ok := collectionTarget(target, kind)
+	return ok && source.Map && inner.Map
 }
 
 // Build creates conversion source code for the given source and target type.
 func (*Map) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if ctx.WantMethodKind == xtype.InSourceIn2Target {
+		return buildMapInto(gen, ctx, sourceID, source, target)
+	}
+
 	targetMap := ctx.Name(target.ID())
 	key, value := ctx.Map()
 
+	block, err := buildMapEntry(gen, ctx, source, target, targetMap, key, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt := buildCollection(ctx, sourceID, source, target, targetMap,
+		jen.Make(target.TypeAsJen(), jen.Len(sourceID.Code.Clone())),
+		jen.For(jen.List(jen.Id(key), jen.Id(value)).Op(":=").Range().Add(sourceID.Code)).
+			Block(block...),
+	)
+
+	return stmt, xtype.VariableID(jen.Id(targetMap)), nil
+}
+
+// buildMapInto merges the source into the map the target points to, existing keys missing in the source are kept.
+func buildMapInto(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	var (
+		targetID   = ctx.TargetID
+		inner      = target.PointerInner
+		targetMap  = ctx.Name(inner.ID())
+		key, value = ctx.Map()
+	)
+
+	block, err := buildMapEntry(gen, ctx, source, inner, targetMap, key, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt := buildCollectionInto(ctx, sourceID, targetID, source, nil,
+		jen.Id(targetMap).Op(":=").Op("*").Add(targetID.Code.Clone()),
+		jen.If(jen.Id(targetMap).Op("==").Nil()).
+			Block(jen.Id(targetMap).Op("=").Make(inner.TypeAsJen(), jen.Len(sourceID.Code.Clone()))),
+		jen.For(jen.List(jen.Id(key), jen.Id(value)).Op(":=").Range().Add(sourceID.Code.Clone())).
+			Block(block...),
+		jen.Op("*").Add(targetID.Code.Clone()).Op("=").Id(targetMap),
+	)
+
+	return stmt, nil, nil
+}
+
+// buildMapEntry creates the statements converting the entry key, value of the source into targetMap.
+func buildMapEntry(gen Generator, ctx *MethodContext, source, target *xtype.Type, targetMap, key, value string) ([]jen.Code, *Error) {
+	ctx.WantMethodKind = xtype.InSourceOutTarget
 	block, newKey, err := gen.Build(ctx, xtype.VariableID(jen.Id(key)), source.MapKey, target.MapKey)
 	if err != nil {
-		return nil, nil, err.Lift(&Path{
+		return nil, err.Lift(&Path{
 			SourceID:   "[]",
 			SourceType: "<mapkey> " + source.MapKey.T.String(),
 			TargetID:   "[]",
 			TargetType: "<mapkey> " + target.MapKey.T.String(),
 		})
 	}
-	valueStmt, valueKey, err := gen.Build(ctx, xtype.VariableID(jen.Id(value)), source.MapValue, target.MapValue)
+	valueStmt, valueKey, err := buildMapValue(gen, ctx, value, source, target)
 	if err != nil {
-		return nil, nil, err.Lift(&Path{
+		return nil, err.Lift(&Path{
 			SourceID:   "[]",
 			SourceType: "<mapvalue> " + source.MapValue.T.String(),
 			TargetID:   "[]",
@@ -37,13 +85,58 @@ func (*Map) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, sour
 		})
 	}
 	block = append(block, valueStmt...)
-	block = append(block, jen.Id(targetMap).Index(newKey.Code).Op("=").Add(valueKey.Code))
+	return append(block, jen.Id(targetMap).Index(newKey.Code).Op("=").Add(valueKey.Code)), nil
+}
 
-	stmt := []jen.Code{
-		jen.Id(targetMap).Op(":=").Make(target.TypeAsJen(), jen.Len(sourceID.Code.Clone())),
-		jen.For(jen.List(jen.Id(key), jen.Id(value)).Op(":=").Range().Add(sourceID.Code)).
-			Block(block...),
+// buildMapValue creates the statements converting the map value. Struct values are converted by reference
+// into a local, because map entries aren't addressable.
+func buildMapValue(gen Generator, ctx *MethodContext, value string, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	defer ctx.enterElement()()
+
+	// declared converter methods and extends take precedence
+	if ok, stmt, id, err := gen.BuildWithExtend(ctx, xtype.VariableID(jen.Id(value)), source.MapValue, target.MapValue); ok {
+		return stmt, id, err
 	}
 
-	return stmt, xtype.VariableID(jen.Id(targetMap)), nil
+	nextSource, nextTarget, enabledZeroCopy := optimizeZeroCopy(ctx, source.MapValue, target.MapValue)
+	// a nil value is converted according to the nil policy
+	if !enabledZeroCopy || (source.MapValue.Pointer && !target.MapValue.Pointer) {
+		return gen.Build(ctx, xtype.VariableID(jen.Id(value)), source.MapValue, target.MapValue)
+	}
+
+	var (
+		targetValue  = ctx.Name(target.MapValue.ID())
+		nextSourceID = jen.Id(value)
+		stmt         []jen.Code
+	)
+	if source.MapValue.Struct {
+		nextSourceID = jen.Op("&").Id(value)
+	}
+
+	ctx.WantMethodKind = xtype.InSourceIn2Target
+	ctx.TargetID = xtype.OtherID(jen.Op("&").Id(targetValue))
+	if target.MapValue.Pointer {
+		ctx.TargetID = xtype.OtherID(jen.Id(targetValue))
+	}
+	mapping, _, err := gen.Build(ctx, xtype.OtherID(nextSourceID), nextSource, nextTarget)
+	ctx.WantMethodKind = xtype.InSourceOutTarget
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case source.MapValue.Pointer:
+		// nil values stay nil
+		mapping = append([]jen.Code{jen.Id(targetValue).Op("=").New(target.MapValue.PointerInner.TypeAsJen())}, mapping...)
+		stmt = append(stmt, jen.Var().Id(targetValue).Add(target.MapValue.TypeAsJen()))
+		stmt = append(stmt, jen.If(jen.Id(value).Op("!=").Nil()).Block(mapping...))
+	case target.MapValue.Pointer:
+		stmt = append(stmt, jen.Id(targetValue).Op(":=").New(target.MapValue.PointerInner.TypeAsJen()))
+		stmt = append(stmt, mapping...)
+	default:
+		stmt = append(stmt, jen.Var().Id(targetValue).Add(target.MapValue.TypeAsJen()))
+		stmt = append(stmt, mapping...)
+	}
+
+	return stmt, xtype.VariableID(jen.Id(targetValue)), nil
 }
@@ -0,0 +1,200 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// MapKey defines how a list is converted into a map and back, see goverter:mapKey.
+type MapKey struct {
+	// Field is the struct field of the list elements used as map key.
+	Field string
+	// Sorted converts the map values into a list ordered by their keys.
+	Sorted bool
+}
+
+// mapKey returns the MapKey of the current target field, or the one declared for the whole method.
+func (m *MethodContext) mapKey() *MapKey {
+	if mapKey, ok := m.MapKeys[m.TargetField]; ok && m.TargetField != "" {
+		return mapKey
+	}
+	return m.MapKeys[""]
+}
+
+// ListToMap handles conversions from a list into a map keyed by a field of the list elements.
+type ListToMap struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*ListToMap) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return source.List && target.Map && kind == xtype.InSourceOutTarget
+}
+
+// MatchesContext returns true, if goverter:mapKey declares the key field.
+func (*ListToMap) MatchesContext(ctx *MethodContext, _, _ *xtype.Type) bool {
+	mapKey := ctx.mapKey()
+	return mapKey != nil && mapKey.Field != ""
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*ListToMap) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	var (
+		mapKey    = ctx.mapKey()
+		element   = source.ListInner
+		targetMap = ctx.Name(target.ID())
+		index     = ctx.Index()
+		elementID = sourceID.Code.Clone().Index(jen.Id(index))
+		block     []jen.Code
+	)
+
+	keyed := element
+	if keyed.Pointer {
+		keyed = keyed.PointerInner
+		// the key of a nil element can't be read
+		block = append(block, jen.If(elementID.Clone().Op("==").Nil()).Block(jen.Continue()))
+	}
+	if !keyed.Struct {
+		return nil, nil, NewError(fmt.Sprintf("Cannot use the key field %s of %s, the list elements must be structs", mapKey.Field, element.T)).Lift(&Path{
+			SourceID:   "[]",
+			SourceType: element.T.String(),
+		})
+	}
+	keyField, fieldErr := keyed.StructField(mapKey.Field, "", false, nil, nil)
+	if fieldErr != nil {
+		return nil, nil, NewError(fmt.Sprintf("Cannot find the key field on the list elements: %s.", fieldErr.Error())).Lift(&Path{
+			SourceID:   "[]",
+			SourceType: element.T.String(),
+		})
+	}
+
+	ctx.WantMethodKind = xtype.InSourceOutTarget
+	keyStmt, keyID, err := gen.Build(ctx, xtype.VariableID(elementID.Clone().Dot(keyField.Name)), keyField.Type, target.MapKey)
+	if err != nil {
+		return nil, nil, err.Lift(&Path{
+			SourceID:   "[]." + keyField.Name,
+			SourceType: keyField.Type.T.String(),
+			TargetID:   "[]",
+			TargetType: "<mapkey> " + target.MapKey.T.String(),
+		})
+	}
+	value, valueSourceID := element, xtype.VariableID(elementID.Clone())
+	if element.Pointer && !target.MapValue.Pointer {
+		value, valueSourceID = keyed, xtype.OtherID(jen.Op("*").Add(elementID.Clone()))
+	}
+	restore := ctx.enterElement()
+	valueStmt, valueID, err := gen.Build(ctx, valueSourceID, value, target.MapValue)
+	restore()
+	if err != nil {
+		return nil, nil, err.Lift(&Path{
+			SourceID:   "[]",
+			SourceType: value.T.String(),
+			TargetID:   "[]",
+			TargetType: "<mapvalue> " + target.MapValue.T.String(),
+		})
+	}
+	block = append(block, keyStmt...)
+	block = append(block, valueStmt...)
+	block = append(block, jen.Id(targetMap).Index(keyID.Code).Op("=").Add(valueID.Code))
+
+	stmt := buildCollection(ctx, sourceID, source, target, targetMap,
+		jen.Make(target.TypeAsJen(), jen.Len(sourceID.Code.Clone())),
+		jen.For(jen.Id(index).Op(":=").Lit(0), jen.Id(index).Op("<").Len(sourceID.Code.Clone()), jen.Id(index).Op("++")).
+			Block(block...),
+	)
+
+	return stmt, xtype.VariableID(jen.Id(targetMap)), nil
+}
+
+// MapToList handles conversions from the values of a map into a list.
+type MapToList struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*MapToList) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return source.Map && target.List && !target.ListFixed && kind == xtype.InSourceOutTarget
+}
+
+// MatchesContext returns true, if goverter:mapKey or goverter:mapKeySorted is declared.
+func (*MapToList) MatchesContext(ctx *MethodContext, _, _ *xtype.Type) bool {
+	return ctx.mapKey() != nil
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*MapToList) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if ctx.mapKey().Sorted {
+		return buildSortedMapToList(gen, ctx, sourceID, source, target)
+	}
+
+	var (
+		targetSlice = ctx.Name(target.ID())
+		_, value    = ctx.Map()
+	)
+
+	ctx.WantMethodKind = xtype.InSourceOutTarget
+	restore := ctx.enterElement()
+	block, valueID, err := gen.Build(ctx, xtype.VariableID(jen.Id(value)), source.MapValue, target.ListInner)
+	restore()
+	if err != nil {
+		return nil, nil, err.Lift(&Path{
+			SourceID:   "[]",
+			SourceType: "<mapvalue> " + source.MapValue.T.String(),
+			TargetID:   "[]",
+			TargetType: target.ListInner.T.String(),
+		})
+	}
+	block = append(block, jen.Id(targetSlice).Op("=").Append(jen.Id(targetSlice), valueID.Code))
+
+	stmt := buildCollection(ctx, sourceID, source, target, targetSlice,
+		jen.Make(target.TypeAsJen(), jen.Lit(0), jen.Len(sourceID.Code.Clone())),
+		jen.For(jen.List(jen.Id("_"), jen.Id(value)).Op(":=").Range().Add(sourceID.Code.Clone())).
+			Block(block...),
+	)
+
+	return stmt, xtype.VariableID(jen.Id(targetSlice)), nil
+}
+
+// buildSortedMapToList converts the map values in the order of their keys.
+func buildSortedMapToList(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if basic, ok := source.MapKey.T.Underlying().(*types.Basic); !ok || basic.Info()&types.IsOrdered == 0 {
+		return nil, nil, NewError(fmt.Sprintf("Cannot sort the map by its keys, the key type %s is not ordered", source.MapKey.T)).Lift(&Path{
+			SourceID:   "[]",
+			SourceType: "<mapkey> " + source.MapKey.T.String(),
+		})
+	}
+
+	var (
+		keysType    = xtype.TypeOf(types.NewSlice(source.MapKey.T))
+		targetSlice = ctx.Name(target.ID())
+		keys        = ctx.Name(keysType.ID())
+		key, _      = ctx.Map()
+		index       = ctx.Index()
+	)
+
+	ctx.WantMethodKind = xtype.InSourceOutTarget
+	// map entries aren't addressable, therefore the value isn't a variable
+	restore := ctx.enterElement()
+	block, valueID, err := gen.Build(ctx, xtype.OtherID(sourceID.Code.Clone().Index(jen.Id(keys).Index(jen.Id(index)))), source.MapValue, target.ListInner)
+	restore()
+	if err != nil {
+		return nil, nil, err.Lift(&Path{
+			SourceID:   "[]",
+			SourceType: "<mapvalue> " + source.MapValue.T.String(),
+			TargetID:   "[]",
+			TargetType: target.ListInner.T.String(),
+		})
+	}
+	block = append(block, jen.Id(targetSlice).Index(jen.Id(index)).Op("=").Add(valueID.Code))
+
+	stmt := buildCollection(ctx, sourceID, source, target, targetSlice,
+		jen.Make(target.TypeAsJen(), jen.Len(sourceID.Code.Clone())),
+		jen.Id(keys).Op(":=").Make(keysType.TypeAsJen(), jen.Lit(0), jen.Len(sourceID.Code.Clone())),
+		jen.For(jen.Id(key).Op(":=").Range().Add(sourceID.Code.Clone())).
+			Block(jen.Id(keys).Op("=").Append(jen.Id(keys), jen.Id(key))),
+		jen.Qual("slices", "Sort").Call(jen.Id(keys)),
+		jen.For(jen.Id(index).Op(":=").Lit(0), jen.Id(index).Op("<").Len(jen.Id(keys)), jen.Id(index).Op("++")).
+			Block(block...),
+	)
+
+	return stmt, xtype.VariableID(jen.Id(targetSlice)), nil
+}
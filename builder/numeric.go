@@ -0,0 +1,161 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// NumericNarrowing defines how numbers are converted into a type that can't represent all values.
+type NumericNarrowing byte
+
+const (
+	// NumericNarrowingNone fails the generation on narrowing conversions.
+	NumericNarrowingNone NumericNarrowing = iota
+	// NumericNarrowingChecked returns an error, if the value can't be represented.
+	NumericNarrowingChecked
+	// NumericNarrowingTruncate uses a plain go conversion.
+	NumericNarrowingTruncate
+)
+
+// Numeric handles conversions between different numeric basic types.
+type Numeric struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*Numeric) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return source.Basic && target.Basic && kind == xtype.InSourceOutTarget &&
+		isNumeric(source.BasicType) && isNumeric(target.BasicType) &&
+		source.BasicType.Kind() != target.BasicType.Kind()
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*Numeric) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	cast := xtype.OtherID(target.TypeAsJen().Call(sourceID.Code.Clone()))
+	if isWidening(source.BasicType, target.BasicType) {
+		return nil, cast, nil
+	}
+
+	switch ctx.NumericNarrowing {
+	case NumericNarrowingTruncate:
+		return nil, cast, nil
+	case NumericNarrowingChecked:
+	default:
+		return nil, nil, NewError(numericNarrowingError(source.T.String(), target.T.String()))
+	}
+
+	var (
+		name = ctx.Name(target.ID())
+		cond *jen.Statement
+	)
+	switch {
+	case isFloat(source.BasicType) && isFloat(target.BasicType):
+		cond = jen.Qual("math", "Abs").Call(jen.Float64().Call(sourceID.Code.Clone())).Op(">").Qual("math", "MaxFloat32")
+	default:
+		// a value can be represented in the target, if it survives the conversion back into the source type
+		cond = source.TypeAsJen().Call(jen.Id(name)).Op("!=").Add(sourceID.Code.Clone())
+		if isInteger(source.BasicType) && isInteger(target.BasicType) {
+			switch {
+			case isSigned(source.BasicType) && !isSigned(target.BasicType):
+				cond = cond.Op("||").Add(sourceID.Code.Clone()).Op("<").Lit(0)
+			case !isSigned(source.BasicType) && isSigned(target.BasicType):
+				cond = cond.Op("||").Id(name).Op("<").Lit(0)
+			}
+		}
+	}
+
+	errID := jen.Qual("fmt", "Errorf").Call(
+		jen.Lit(fmt.Sprintf("cannot convert %%v to %s without loss", target.T)),
+		sourceID.Code.Clone(),
+	)
+	ret, err := gen.ReturnError(ctx, fmt.Sprintf("the checked conversion from %s to %s", source.T, target.T), errID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt := []jen.Code{
+		jen.Id(name).Op(":=").Add(cast.Code),
+		jen.If(cond).Block(ret...),
+	}
+
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+// intSizes contains the minimal and maximal size in bytes of the integer kinds, int and uint
+// depend on the platform.
+var intSizes = map[types.BasicKind][2]int{
+	types.Int:    {4, 8},
+	types.Int8:   {1, 1},
+	types.Int16:  {2, 2},
+	types.Int32:  {4, 4},
+	types.Int64:  {8, 8},
+	types.Uint:   {4, 8},
+	types.Uint8:  {1, 1},
+	types.Uint16: {2, 2},
+	types.Uint32: {4, 4},
+	types.Uint64: {8, 8},
+}
+
+// mantissaBits contains the bits of an integer that can be represented exactly by the float kinds.
+var mantissaBits = map[types.BasicKind]int{
+	types.Float32: 24,
+	types.Float64: 53,
+}
+
+// isWidening returns true, if every value of source can be represented in target on all platforms.
+func isWidening(source, target *types.Basic) bool {
+	switch {
+	case isInteger(source) && isInteger(target):
+		sourceMax := intSizes[source.Kind()][1]
+		targetMin := intSizes[target.Kind()][0]
+		switch {
+		case isSigned(source) == isSigned(target):
+			return targetMin >= sourceMax
+		case !isSigned(source):
+			return targetMin > sourceMax
+		}
+		return false
+	case isInteger(source) && isFloat(target):
+		bits := intSizes[source.Kind()][1] * 8
+		if isSigned(source) {
+			bits--
+		}
+		return bits <= mantissaBits[target.Kind()]
+	case isFloat(source) && isFloat(target):
+		return source.Kind() == types.Float32
+	}
+	return false
+}
+
+func isNumeric(t *types.Basic) bool {
+	return isInteger(t) || isFloat(t)
+}
+
+func isInteger(t *types.Basic) bool {
+	_, ok := intSizes[t.Kind()]
+	return ok
+}
+
+func isFloat(t *types.Basic) bool {
+	_, ok := mantissaBits[t.Kind()]
+	return ok
+}
+
+func isSigned(t *types.Basic) bool {
+	return t.Info()&types.IsUnsigned == 0
+}
+
+func numericNarrowingError(source, target string) string {
+	return fmt.Sprintf(`Cannot convert %s to %s because not all values can be represented.
+
+Generate a range check returning an error with:
+
+    goverter:numericNarrowing checked
+
+Or use a plain conversion with:
+
+    goverter:numericNarrowing truncate
+
+See https://github.com/pengdaCN/goverter/blob/main/addition.md`, source, target)
+}
@@ -0,0 +1,240 @@
+package builder
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// Optional describes a generic wrapper type like Option[T] declared with goverter:optional.
+type Optional struct {
+	// Get is the method or field returning the wrapped value.
+	Get       string
+	GetMethod bool
+	// IsSome is the method or field reporting whether a value is present.
+	IsSome       string
+	IsSomeMethod bool
+	// Some creates a wrapper of a value, if nil the wrapper is created with a composite literal of
+	// the Get and IsSome fields.
+	Some *jen.Statement
+	// None creates an empty wrapper, if nil the zero value is used.
+	None *jen.Statement
+}
+
+// OptionalKey returns the key of the generic origin of t used in MethodContext.Optionals.
+func OptionalKey(t *types.Named) string {
+	obj := t.Origin().Obj()
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// OptionalWrapper handles conversions from and to the optional types declared with goverter:optional.
+type OptionalWrapper struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*OptionalWrapper) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return kind == xtype.InSourceOutTarget && (isGenericInstance(source) || isGenericInstance(target))
+}
+
+// MatchesContext returns true, if source or target is declared with goverter:optional.
+func (*OptionalWrapper) MatchesContext(ctx *MethodContext, source, target *xtype.Type) bool {
+	return ctx.optional(source) != nil || ctx.optional(target) != nil
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*OptionalWrapper) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if types.Identical(source.T, target.T) {
+		return nil, sourceID, nil
+	}
+
+	if valid, valueID, valueType, valueName, ok := ctx.nullableSource(sourceID, source); ok {
+		return buildUnwrap(gen, ctx, source, target, valid, valueName, valueID, valueType)
+	}
+
+	opt := ctx.optional(target)
+	return buildWrap(gen, ctx, sourceID, source, target, opt.valueName(), typeArg(target), ctx.optionalWrap(target))
+}
+
+// valueName returns the name of the wrapped value used in error messages.
+func (o *Optional) valueName() string {
+	if o.GetMethod {
+		return o.Get + "()"
+	}
+	return o.Get
+}
+
+// optional returns the goverter:optional declaration of t.
+func (m *MethodContext) optional(t *xtype.Type) *Optional {
+	if !isGenericInstance(t) {
+		return nil
+	}
+	return m.Optionals[OptionalKey(t.NamedType)]
+}
+
+// optionalWrap returns how a value is wrapped into the optional target.
+func (m *MethodContext) optionalWrap(target *xtype.Type) func(id *jen.Statement) *jen.Statement {
+	opt := m.optional(target)
+	return func(id *jen.Statement) *jen.Statement {
+		if opt.Some != nil {
+			return opt.Some.Clone().Types(typeArg(target).TypeAsJen()).Call(id)
+		}
+		return target.TypeAsJen().Values(jen.Dict{
+			jen.Id(opt.Get):    id,
+			jen.Id(opt.IsSome): jen.True(),
+		})
+	}
+}
+
+// none returns the empty wrapper of target, nil is returned if the zero value is empty.
+func (m *MethodContext) none(target *xtype.Type) *jen.Statement {
+	opt := m.optional(target)
+	if opt == nil || opt.None == nil {
+		return nil
+	}
+	return opt.None.Clone().Types(typeArg(target).TypeAsJen()).Call()
+}
+
+// nullableSource returns the valid check and the value of the wrapper types Option[T] or sql.NullString.
+func (m *MethodContext) nullableSource(sourceID *xtype.JenID, source *xtype.Type) (valid *jen.Statement, valueID *xtype.JenID, valueType *xtype.Type, valueName string, ok bool) {
+	if opt := m.optional(source); opt != nil {
+		value := sourceID.Code.Clone().Dot(opt.Get)
+		if opt.GetMethod {
+			value = value.Call()
+		}
+		valid := sourceID.Code.Clone().Dot(opt.IsSome)
+		if opt.IsSomeMethod {
+			valid = valid.Call()
+		}
+		return valid, xtype.OtherID(value), typeArg(source), opt.valueName(), true
+	}
+	if value, ok := sqlNullValue(source); ok {
+		valueID := xtype.VariableID(sourceID.Code.Clone().Dot(value.Name()))
+		return sourceID.Code.Clone().Dot("Valid"), valueID, xtype.TypeOf(value.Type()), value.Name(), true
+	}
+	return nil, nil, nil, "", false
+}
+
+// nullable returns true, if target can represent a missing value.
+func (m *MethodContext) nullable(target *xtype.Type) bool {
+	_, isSQLNull := sqlNullValue(target)
+	return target.Pointer || isSQLNull || m.optional(target) != nil
+}
+
+// buildUnwrap converts the value of a wrapper type like Option[T] or sql.NullString, if it's valid.
+func buildUnwrap(gen Generator, ctx *MethodContext, source, target *xtype.Type, valid *jen.Statement, valueName string, valueID *xtype.JenID, valueType *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	nullable := ctx.nullable(target)
+	if !nullable && ctx.NilPolicy == NilPolicyNone {
+		return nil, nil, NewError(nilPolicyError(source.T.String(), target.T.String()))
+	}
+
+	var (
+		name  = ctx.Name(target.ID())
+		inner = target
+	)
+	if target.Pointer {
+		inner = target.PointerInner
+	}
+
+	block, id, err := buildInnerValue(gen, ctx, valueID, valueType, inner)
+	if err != nil {
+		return nil, nil, err.Lift(&Path{
+			Prefix:     ".",
+			SourceID:   valueName,
+			SourceType: valueType.T.String(),
+			TargetID:   "",
+			TargetType: target.T.String(),
+		})
+	}
+	if target.Pointer {
+		innerName := ctx.Name(inner.ID())
+		block = append(block, jen.Id(innerName).Op(":=").Add(id.Code))
+		id = xtype.OtherID(jen.Op("&").Id(innerName))
+	}
+	block = append(block, jen.Id(name).Op("=").Add(id.Code))
+
+	ifStmt := jen.If(valid).Block(block...)
+	switch {
+	case ctx.none(target) != nil:
+		ifStmt = ifStmt.Else().Block(jen.Id(name).Op("=").Add(ctx.none(target)))
+	case !nullable && ctx.NilPolicy == NilPolicyError:
+		ret, err := nilReturnError(gen, ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		ifStmt = ifStmt.Else().Block(ret...)
+	}
+
+	stmt := []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		ifStmt,
+	}
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+// buildWrap converts the source into the value of a wrapper type like Option[T] or sql.NullString, a nil
+// source or a nullable source without value is converted into an empty wrapper.
+func buildWrap(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type, valueName string, valueType *xtype.Type, wrap func(id *jen.Statement) *jen.Statement) ([]jen.Code, *xtype.JenID, *Error) {
+	var (
+		nextID     = sourceID
+		next       = source
+		nextSource = ""
+		valid      *jen.Statement
+	)
+	if source.Pointer {
+		nextID = derefID(sourceID, source.PointerInner)
+		next = source.PointerInner
+		nextSource = "*"
+		valid = sourceID.Code.Clone().Op("!=").Nil()
+	} else if sourceValid, sourceValueID, sourceValue, sourceValueName, ok := ctx.nullableSource(sourceID, source); ok {
+		nextID = sourceValueID
+		next = sourceValue
+		nextSource = "." + sourceValueName
+		valid = sourceValid
+	}
+
+	block, id, err := buildInnerValue(gen, ctx, nextID, next, valueType)
+	if err != nil {
+		return nil, nil, err.Lift(&Path{
+			SourceID:   nextSource,
+			SourceType: next.T.String(),
+			TargetID:   "." + valueName,
+			TargetType: valueType.T.String(),
+		})
+	}
+
+	if valid == nil {
+		return block, xtype.OtherID(wrap(id.Code)), nil
+	}
+
+	name := ctx.Name(target.ID())
+	block = append(block, jen.Id(name).Op("=").Add(wrap(id.Code)))
+	ifStmt := jen.If(valid).Block(block...)
+	if none := ctx.none(target); none != nil {
+		ifStmt = ifStmt.Else().Block(jen.Id(name).Op("=").Add(none))
+	}
+
+	stmt := []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		ifStmt,
+	}
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+// buildInnerValue converts the wrapped value, identical types are assigned as they are.
+func buildInnerValue(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if types.Identical(source.T, target.T) {
+		return nil, sourceID, nil
+	}
+	return gen.Build(ctx, sourceID, source, target)
+}
+
+func isGenericInstance(t *xtype.Type) bool {
+	return t.Named && t.NamedType.TypeArgs().Len() == 1
+}
+
+func typeArg(t *xtype.Type) *xtype.Type {
+	return xtype.TypeOf(t.NamedType.TypeArgs().At(0))
+}
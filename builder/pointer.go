@@ -1,11 +1,15 @@
 package builder
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/dave/jennifer/jen"
 	"github.com/pengdaCN/goverter/xtype"
 )
 
-// Pointer handles pointer types.
+// Pointer handles pointer types. Multi-level pointers are converted level by level,
+// each level gets its own nil check.
 type Pointer struct{}
 
 // Matches returns true, if the builder can create handle the given types.
@@ -21,7 +25,7 @@ func (*Pointer) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID,
 		nextSourceID    *xtype.JenID
 		nextSource      = source
 		nextTarget      = target
-		enabledZeroCopy = source.PointerInner.Struct && target.PointerInner.Struct
+		enabledZeroCopy = source.PointerInner.Struct && target.PointerInner.Struct && !sameType(ctx, source.PointerInner, target.PointerInner) && !convertible(ctx, source.PointerInner, target.PointerInner)
 	)
 
 	if enabledZeroCopy {
@@ -29,7 +33,7 @@ func (*Pointer) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID,
 		ctx.TargetID = xtype.OtherID(jen.Op("&").Add(jen.Id(innerVar)))
 		ctx.WantMethodKind = xtype.InSourceIn2Target
 	} else {
-		nextSourceID = xtype.OtherID(jen.Op("*").Add(sourceID.Code.Clone()))
+		nextSourceID = derefID(sourceID, source.PointerInner)
 		nextSource = source.PointerInner
 		nextTarget = target.PointerInner
 	}
@@ -85,14 +89,16 @@ func (*TargetPointer) Build(gen Generator, ctx *MethodContext, sourceID *xtype.J
 	var (
 		innerVar        = ctx.Name(target.PointerInner.ID())
 		nextSource      = source
-		nextTarget      = target
+		nextTarget      = target.PointerInner
 		nextSourceID    = sourceID
-		enabledZeroCopy = source.PointerInner.Struct && target.PointerInner.Struct
+		enabledZeroCopy = source.Struct && target.PointerInner.Struct && !sameType(ctx, source, target.PointerInner) && !convertible(ctx, source, target.PointerInner)
 	)
 
 	ctx.TargetID = xtype.OtherID(jen.Id(innerVar))
 	if enabledZeroCopy {
+		ctx.TargetID = xtype.OtherID(jen.Op("&").Id(innerVar))
 		nextSource = xtype.WrapWithPtr(source)
+		nextTarget = target
 		nextSourceID = xtype.OtherID(jen.Op("&").Add(sourceID.Code.Clone()))
 		ctx.WantMethodKind = xtype.InSourceIn2Target
 	}
@@ -122,3 +128,103 @@ func (*TargetPointer) Build(gen Generator, ctx *MethodContext, sourceID *xtype.J
 
 	return stmt, xtype.OtherID(jen.Op("&").Id(innerVar)), nil
 }
+
+// NilPolicy defines how a nil pointer is converted into a non pointer type.
+type NilPolicy byte
+
+const (
+	// NilPolicyNone fails the generation, if a nil pointer can't be converted.
+	NilPolicyNone NilPolicy = iota
+	// NilPolicyZero uses the zero value of the target.
+	NilPolicyZero
+	// NilPolicyError returns an error naming the nil source.
+	NilPolicyError
+	// NilPolicySkip skips the assignment of struct fields, otherwise it behaves like NilPolicyZero.
+	NilPolicySkip
+)
+
+// SourcePointer handles type were only the source is a pointer.
+type SourcePointer struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*SourcePointer) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return source.Pointer && !target.Pointer && kind == xtype.InSourceOutTarget
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*SourcePointer) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if ctx.NilPolicy == NilPolicyNone {
+		return nil, nil, NewError(nilPolicyError(source.T.String(), target.T.String()))
+	}
+
+	name := ctx.Name(target.ID())
+
+	nextBlock, id, err := gen.Build(ctx, derefID(sourceID, source.PointerInner), source.PointerInner, target)
+	if err != nil {
+		return nil, nil, err.Lift(&Path{
+			SourceID:   "*",
+			SourceType: source.PointerInner.T.String(),
+			TargetID:   "",
+			TargetType: target.T.String(),
+		})
+	}
+	nextBlock = append(nextBlock, jen.Id(name).Op("=").Add(id.Code))
+
+	ifStmt := jen.If(sourceID.Code.Clone().Op("!=").Nil()).Block(nextBlock...)
+	if ctx.NilPolicy == NilPolicyError {
+		ret, err := nilReturnError(gen, ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		ifStmt = ifStmt.Else().Block(ret...)
+	}
+
+	stmt := []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		ifStmt,
+	}
+
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+// derefID dereferences the sourceID, lists and maps are wrapped in parentheses, so that they can be indexed.
+func derefID(sourceID *xtype.JenID, inner *xtype.Type) *xtype.JenID {
+	deref := jen.Op("*").Add(sourceID.Code.Clone())
+	if inner.List || inner.Map {
+		return xtype.OtherID(jen.Parens(deref))
+	}
+	return xtype.OtherID(deref)
+}
+
+// nilReturnError creates the statement returning an error naming the field path of the nil source.
+func nilReturnError(gen Generator, ctx *MethodContext) ([]jen.Code, *Error) {
+	name := fieldPathName(ctx.FieldPath)
+	errID := jen.Qual("errors", "New").Call(jen.Lit(name + " is nil"))
+	return gen.ReturnError(ctx, "the nil check of "+name, errID)
+}
+
+// FieldPathPrefix returns the field path prepended to the errors of a converter method called by the current method,
+// it's empty if the errors are returned as they are.
+func FieldPathPrefix(ctx *MethodContext) string {
+	if ctx.NilPolicy != NilPolicyError || ctx.FieldPath == "" {
+		return ""
+	}
+	return fieldPathName(ctx.FieldPath)
+}
+
+func fieldPathName(path string) string {
+	if path == "" || strings.HasPrefix(path, "[]") {
+		return xtype.In + path
+	}
+	return path
+}
+
+func nilPolicyError(source, target string) string {
+	return fmt.Sprintf(`Cannot convert %s to %s because the source may be nil.
+
+Define how a nil source is converted with:
+
+    goverter:nilPolicy zero|error|skip
+
+See https://github.com/pengdaCN/goverter/blob/main/addition.md`, source, target)
+}
@@ -0,0 +1,42 @@
+package builder
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// CopySameType defines how identical source and target types are copied.
+type CopySameType byte
+
+const (
+	// CopySameTypeDeep converts identical types like any other types, slices, maps and pointers are copied.
+	CopySameTypeDeep CopySameType = iota
+	// CopySameTypeShallow assigns identical types, the target shares slices, maps and pointers with the source.
+	CopySameTypeShallow
+)
+
+// SameType handles identical types with goverter:copySameType shallow.
+type SameType struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*SameType) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return kind == xtype.InSourceOutTarget && !source.TypeParam && types.Identical(source.T, target.T)
+}
+
+// MatchesContext returns true, if shallow copies are enabled and the settings of ctx don't change the result.
+func (*SameType) MatchesContext(ctx *MethodContext, source, target *xtype.Type) bool {
+	return ctx.CopySameType == CopySameTypeShallow && directContext(ctx, source, target)
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*SameType) Build(_ Generator, _ *MethodContext, sourceID *xtype.JenID, _, _ *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	return nil, xtype.OtherID(sourceID.Code.Clone()), nil
+}
+
+// sameType returns true, if SameType handles the conversion from source to target.
+func sameType(ctx *MethodContext, source, target *xtype.Type) bool {
+	s := SameType{}
+	return s.Matches(source, target, xtype.InSourceOutTarget) && s.MatchesContext(ctx, source, target)
+}
@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+const sqlPackage = "database/sql"
+
+// SQLNull handles conversions between the database/sql Null types like sql.NullString or sql.Null[T] and
+// pointers or values of their inner type.
+type SQLNull struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*SQLNull) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	if kind != xtype.InSourceOutTarget {
+		return false
+	}
+
+	_, sourceOk := sqlNullValue(source)
+	_, targetOk := sqlNullValue(target)
+	return sourceOk != targetOk
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*SQLNull) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if _, ok := sqlNullValue(source); ok {
+		valid, valueID, valueType, valueName, _ := ctx.nullableSource(sourceID, source)
+		return buildUnwrap(gen, ctx, source, target, valid, valueName, valueID, valueType)
+	}
+
+	value, _ := sqlNullValue(target)
+	wrap := func(id *jen.Statement) *jen.Statement {
+		return target.TypeAsJen().Values(jen.Dict{
+			jen.Id(value.Name()): id,
+			jen.Id("Valid"):      jen.True(),
+		})
+	}
+	return buildWrap(gen, ctx, sourceID, source, target, value.Name(), xtype.TypeOf(value.Type()), wrap)
+}
+
+// sqlNullValue returns the value field of the database/sql Null types, those are structs with the value and a
+// Valid bool field.
+func sqlNullValue(t *xtype.Type) (*types.Var, bool) {
+	if !t.Named || !t.Struct || t.StructType.NumFields() != 2 {
+		return nil, false
+	}
+	if pkg := t.NamedType.Obj().Pkg(); pkg == nil || pkg.Path() != sqlPackage {
+		return nil, false
+	}
+
+	var value *types.Var
+	for i := 0; i < t.StructType.NumFields(); i++ {
+		field := t.StructType.Field(i)
+		if field.Name() == "Valid" {
+			if !types.Identical(field.Type(), types.Typ[types.Bool]) {
+				return nil, false
+			}
+			continue
+		}
+		value = field
+	}
+	return value, value != nil
+}
@@ -0,0 +1,49 @@
+package builder
+
+import (
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// String handles the native conversions between strings, byte slices and rune slices.
+type String struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*String) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	if kind != xtype.InSourceOutTarget || !types.ConvertibleTo(source.T, target.T) {
+		return false
+	}
+
+	switch {
+	case isString(source):
+		return isByteOrRuneSlice(target)
+	case isString(target):
+		return isByteOrRuneSlice(source)
+	}
+	return isByteOrRuneSlice(source) && isByteOrRuneSlice(target) && !types.Identical(source.T, target.T)
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*String) Build(_ Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if ctx.CopyBytes && source.List && target.List {
+		// the conversion between slices shares the backing array
+		return nil, xtype.OtherID(jen.Append(target.TypeAsJen().Call(jen.Nil()), sourceID.Code.Clone().Op("..."))), nil
+	}
+
+	return nil, xtype.OtherID(target.TypeAsJen().Call(sourceID.Code.Clone())), nil
+}
+
+func isString(t *xtype.Type) bool {
+	return t.Basic && t.BasicType.Info()&types.IsString != 0
+}
+
+func isByteOrRuneSlice(t *xtype.Type) bool {
+	if !t.List || t.ListFixed || !t.ListInner.Basic {
+		return false
+	}
+
+	kind := t.ListInner.BasicType.Kind()
+	return kind == types.Byte || kind == types.Rune
+}
@@ -69,6 +69,7 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 
 		innerSource = source.PointerInner
 		innerTarget = target.PointerInner
+		parentPath  = ctx.FieldPath
 	)
 
 	for i := 0; i < innerTarget.StructType.NumFields(); i++ {
@@ -80,6 +81,8 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 		nextSourceID := sourceID
 		nextSource := source
 		ctx.TargetID = xtype.OtherID(targetFieldRef.Clone())
+		ctx.TargetField = targetField.Name()
+		ctx.FieldPath = parentPath
 
 		if _, ignore := ctx.IgnoredFields[targetField.Name()]; ignore {
 			continue
@@ -109,16 +112,16 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 		{
 			var (
 				mapStmt []jen.Code
-				// lift    []*Path
-				nextID *jen.Statement
-				err    *Error
+				lift    []*Path
+				nextID  *jen.Statement
+				err     *Error
 			)
 
 			findCtx := ctx.EnterWithNamer()
-			findCtx.Signature.Source = innerSource.T.String()
-			findCtx.Signature.Target = innerTarget.T.String()
+			findCtx.Signature.Source = xtype.TypeString(innerSource.T)
+			findCtx.Signature.Target = xtype.TypeString(innerTarget.T)
 
-			nextID, nextSource, mapStmt, _, err = mapField(findCtx, targetField, targetFieldTag, sourceID, innerSource, innerTarget)
+			nextID, nextSource, mapStmt, lift, err = mapField(findCtx, targetField, targetFieldTag, sourceID, innerSource, innerTarget)
 			if err != nil {
 				if ctx.NoStrict {
 					log.Printf("(%s.%s)warn: Cannot match the target field with the source entry %s\n", gen.Name(), ctx.ID, strings.Join([]string{target.T.String(), targetField.Name()}, "."))
@@ -129,6 +132,7 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 			}
 			nextSourceID = xtype.VariableID(nextID)
 			stmt = append(stmt, mapStmt...)
+			ctx.FieldPath = joinFieldPath(parentPath, lift)
 		}
 
 	assign:
@@ -139,6 +143,8 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 			ok              bool
 			sourceIsPtr     bool
 			nextIsPtr       bool
+			derefSource     bool
+			_nextSourceID   = nextSourceID
 			_nextSource     *xtype.Type
 			_nextTarget     *xtype.Type
 			enabledZeroCopy bool
@@ -154,23 +160,26 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 				sourceIsPtr = true
 			}
 
-			if nextTarget.Pointer {
+			// only in-place extends need an allocated target
+			if nextTarget.Pointer && fieldID == nil {
 				nextIsPtr = true
 			}
 
 			goto assignStmt
 		}
 
-		_nextSource, _nextTarget, enabledZeroCopy = optimizeZeroCopy(nextSource, nextTarget)
+		_nextSource, _nextTarget, enabledZeroCopy = optimizeZeroCopy(ctx, nextSource, nextTarget)
 		if enabledZeroCopy {
 			ctx.WantMethodKind = xtype.InSourceIn2Target
 			ctx.TargetID = xtype.OtherID(targetFieldRef.Clone())
 
 			if nextSource.Pointer {
 				sourceIsPtr = true
+				derefSource = !nextTarget.Pointer
 			} else {
 				nextSourceID = xtype.OtherID(jen.Op("&").Add(nextSourceID.Code.Clone()))
 			}
+			_nextSourceID = nextSourceID
 
 			if nextTarget.Pointer {
 				stmt = append(stmt, targetFieldRef.Clone().Op("=").Add(jen.New(_nextTarget.PointerInner.TypeAsJen())))
@@ -181,9 +190,16 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 			ctx.WantMethodKind = xtype.InSourceOutTarget
 			_nextSource = nextSource
 			_nextTarget = nextTarget
+
+			// the assignment is skipped, if the source is nil
+			if ctx.NilPolicy == NilPolicySkip && nextSource.Pointer && !nextTarget.Pointer {
+				sourceIsPtr = true
+				_nextSource = nextSource.PointerInner
+				_nextSourceID = derefID(nextSourceID, _nextSource)
+			}
 		}
 
-		fieldStmt, fieldID, err = gen.Build(ctx, nextSourceID, _nextSource, _nextTarget)
+		fieldStmt, fieldID, err = gen.Build(ctx, _nextSourceID, _nextSource, _nextTarget)
 		if err != nil {
 			return nil, nil, err.Lift(&Path{
 				Prefix:     ".",
@@ -209,14 +225,28 @@ func (z *ZeroCopyStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtyp
 				fieldStmt = append(fieldStmt, targetFieldRef.Clone().Op("=").Add(fieldID.Code))
 			}
 
-			stmt = append(stmt, jen.
+			ifStmt := jen.
 				If(
 					nextSourceID.Code.Clone().Op("!=").Nil(),
 				).
 				Block(
 					fieldStmt...,
-				),
-			)
+				)
+
+			if derefSource {
+				switch ctx.NilPolicy {
+				case NilPolicyZero:
+					ifStmt = ifStmt.Else().Block(targetFieldRef.Clone().Op("=").Add(nextTarget.TypeAsJen()).Values())
+				case NilPolicyError:
+					ret, err := nilReturnError(gen, ctx)
+					if err != nil {
+						return nil, nil, err
+					}
+					ifStmt = ifStmt.Else().Block(ret...)
+				}
+			}
+
+			stmt = append(stmt, ifStmt)
 		} else {
 			stmt = append(stmt, fieldStmt...)
 			if fieldID != nil {
@@ -242,6 +272,17 @@ func (t *TargetStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtype.
 		}
 	)
 
+	switch ctx.NilPolicy {
+	case NilPolicyNone:
+		return nil, nil, NewError(nilPolicyError(source.T.String(), target.T.String()))
+	case NilPolicyError:
+		ret, err := nilReturnError(gen, ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		stmt = append(stmt, jen.If(sourceID.Code.Clone().Op("==").Nil()).Block(ret...))
+	}
+
 	ctx.TargetID = xtype.OtherID(jen.Op("&").Add(jen.Id(name)))
 	ctx.WantMethodKind = xtype.InSourceIn2Target
 
@@ -260,6 +301,18 @@ func (t *TargetStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtype.
 	return stmt, xtype.VariableID(jen.Id(name)), nil
 }
 
+// joinFieldPath appends the source fields of lift to parent.
+func joinFieldPath(parent string, lift []*Path) string {
+	path := parent
+	for _, step := range lift {
+		if path != "" {
+			path += "."
+		}
+		path += step.SourceID
+	}
+	return path
+}
+
 func mapField(
 	ctx *MethodContext,
 	targetField *types.Var,
@@ -275,7 +328,7 @@ func mapField(
 	var lift []*Path
 
 	mappedName, hasOverride := searchRefPathWithMapping(source, ctx, targetField.Name(), targetFiledTag, ctx.SearchTag)
-	if ctx.Signature.Target != target.T.String() || !hasOverride {
+	if ctx.Signature.Target != xtype.TypeString(target.T) || !hasOverride {
 		sourceMatch, err := source.StructField(targetField.Name(), targetFiledTag, ctx.MatchIgnoreCase, ctx.IgnoredFields, ctx.SearchTag)
 		if err == nil {
 			nextID := sourceID.Code.Clone().Dot(sourceMatch.Name)
@@ -305,7 +358,11 @@ func mapField(
 	nextID := sourceID.Code
 	nextSource := source
 	for i := 0; i < len(path); i++ {
-		if nextSource.Pointer {
+		for depth := 0; nextSource.Pointer; depth++ {
+			// the selector dereferences only one level, further levels are dereferenced explicitly
+			if depth > 0 {
+				nextID = jen.Parens(jen.Op("*").Add(nextID.Clone()))
+			}
 			addCondition := nextID.Clone().Op("!=").Nil()
 			if condition == nil {
 				condition = addCondition
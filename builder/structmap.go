@@ -0,0 +1,317 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+	"log"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// StructToMap handles conversions from structs to map[string]any and map[string]string.
+type StructToMap struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*StructToMap) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return kind == xtype.InSourceOutTarget && source.Struct && isStringKeyMap(target)
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*StructToMap) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	var (
+		name   = ctx.Name(target.ID())
+		assign []jen.Code
+		keys   int
+	)
+	for i := 0; i < source.StructType.NumFields(); i++ {
+		field := source.StructType.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		key, _, ok := structMapKey(ctx, field, source.StructType.Tag(i))
+		if !ok {
+			continue
+		}
+
+		keys++
+		fieldType := xtype.TypeOf(field.Type())
+		fieldID := xtype.VariableID(sourceID.Code.Clone().Dot(field.Name()))
+		if isEmptyInterface(target.MapValue) {
+			assign = append(assign, jen.Id(name).Index(jen.Lit(key)).Op("=").Add(fieldID.Code))
+			continue
+		}
+
+		stmt, id, err := formatString(gen, ctx, fieldID, fieldType, target.MapValue)
+		if err != nil {
+			return nil, nil, err.Lift(&Path{
+				Prefix:     ".",
+				SourceID:   field.Name(),
+				SourceType: fieldType.T.String(),
+				TargetID:   fmt.Sprintf("[%q]", key),
+				TargetType: target.MapValue.T.String(),
+			})
+		}
+		assign = append(assign, stmt...)
+		assign = append(assign, jen.Id(name).Index(jen.Lit(key)).Op("=").Add(id.Code))
+	}
+
+	stmt := []jen.Code{jen.Id(name).Op(":=").Make(target.TypeAsJen(), jen.Lit(keys))}
+	stmt = append(stmt, assign...)
+
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+// MapToStruct handles conversions from map[string]any and map[string]string to structs.
+type MapToStruct struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*MapToStruct) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return kind == xtype.InSourceOutTarget && isStringKeyMap(source) && target.Struct
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*MapToStruct) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	var (
+		name  = ctx.Name(target.ID())
+		value = ctx.Name("value")
+		stmt  = []jen.Code{jen.Var().Id(name).Add(target.TypeAsJen())}
+	)
+	for i := 0; i < target.StructType.NumFields(); i++ {
+		field := target.StructType.Field(i)
+		if !field.Exported() {
+			if _, ignore := ctx.IgnoredFields[field.Name()]; ignore {
+				continue
+			}
+			if ctx.NoStrict {
+				if !ctx.IgnoreUnexported {
+					log.Printf("(%s.%s) warn: Cannot set value for unexported field: %s\n", gen.Name(), ctx.ID, strings.Join([]string{target.T.String(), field.Name()}, "."))
+				}
+				continue
+			}
+
+			cause := unexportedStructError(field.Name(), source.T.String(), target.T.String())
+			return nil, nil, NewError(cause).Lift(&Path{
+				Prefix:     ".",
+				SourceID:   "???",
+				TargetID:   field.Name(),
+				TargetType: field.Type().String(),
+			})
+		}
+		if _, ok := ctx.IdentityMapping[field.Name()]; ok {
+			return nil, nil, NewError(fmt.Sprintf("Cannot use goverter:mapIdentity for %s.%s, the source %s has no fields.", target.T, field.Name(), source.T)).Lift(&Path{
+				Prefix:     ".",
+				SourceID:   "???",
+				TargetID:   field.Name(),
+				TargetType: field.Type().String(),
+			})
+		}
+		key, optional, ok := structMapKey(ctx, field, target.StructType.Tag(i))
+		if !ok {
+			continue
+		}
+		if mapped, isMapped := ctx.Mapping[field.Name()]; isMapped {
+			// goverter:map declares the key of the field
+			key = mapped
+		}
+
+		fieldType := xtype.TypeOf(field.Type())
+		fieldRef := jen.Id(name).Dot(field.Name())
+		valueID := xtype.VariableID(jen.Id(value))
+
+		var (
+			block []jen.Code
+			id    *xtype.JenID
+			err   *Error
+		)
+		switch {
+		case isEmptyInterface(source.MapValue) && isEmptyInterface(fieldType):
+			id = valueID
+		case isEmptyInterface(source.MapValue):
+			block, id, err = assertValue(gen, ctx, valueID, key, fieldType)
+		default:
+			block, id, err = parseString(gen, ctx, valueID, key, source.MapValue, fieldType)
+		}
+		if err != nil {
+			return nil, nil, err.Lift(&Path{
+				Prefix:     ".",
+				SourceID:   fmt.Sprintf("[%q]", key),
+				SourceType: source.MapValue.T.String(),
+				TargetID:   field.Name(),
+				TargetType: fieldType.T.String(),
+			})
+		}
+		block = append(block, fieldRef.Op("=").Add(id.Code))
+
+		ifStmt := jen.If(jen.List(jen.Id(value), jen.Id("ok")).Op(":=").Add(sourceID.Code.Clone()).Index(jen.Lit(key)), jen.Id("ok")).Block(block...)
+		if !optional {
+			errID := jen.Qual("errors", "New").Call(jen.Lit(fmt.Sprintf("missing key %q for %s.%s", key, target.T, field.Name())))
+			ret, err := gen.ReturnError(ctx, fmt.Sprintf("the conversion from %s to %s", source.T, target.T), errID)
+			if err != nil {
+				return nil, nil, err
+			}
+			ifStmt = ifStmt.Else().Block(ret...)
+		}
+		stmt = append(stmt, ifStmt)
+	}
+
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+// structMapKey returns the map key of a struct field, it's either the name of the first goverter:tag or the field
+// name. Fields with the tag name "-" are skipped and fields with the option omitempty are optional.
+func structMapKey(ctx *MethodContext, field *types.Var, tag string) (key string, optional, ok bool) {
+	if _, ignore := ctx.IgnoredFields[field.Name()]; ignore {
+		return "", false, false
+	}
+
+	name, options := xtype.TagName(tag, ctx.SearchTag)
+	if name == "-" && len(options) == 0 {
+		return "", false, false
+	}
+	for _, option := range options {
+		if option == "omitempty" {
+			optional = true
+		}
+	}
+	if name == "" {
+		name = field.Name()
+	}
+	return name, optional, true
+}
+
+// assertValue creates a type assertion of an any map value.
+func assertValue(gen Generator, ctx *MethodContext, valueID *xtype.JenID, key string, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	name := ctx.Name(target.ID())
+	errID := jen.Qual("fmt", "Errorf").Call(
+		jen.Lit(fmt.Sprintf("key %q has type %%T, expected %s", key, target.T)),
+		valueID.Code.Clone(),
+	)
+	ret, err := gen.ReturnError(ctx, fmt.Sprintf("the type assertion to %s", target.T), errID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt := []jen.Code{
+		jen.List(jen.Id(name), jen.Id("ok")).Op(":=").Add(valueID.Code.Clone()).Assert(target.TypeAsJen()),
+		jen.If(jen.Op("!").Id("ok")).Block(ret...),
+	}
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+// parseString parses a string map value with strconv, other types are converted with the generator.
+func parseString(gen Generator, ctx *MethodContext, valueID *xtype.JenID, key string, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if !target.Basic || isEnum(target) {
+		return gen.Build(ctx, valueID, source, target)
+	}
+
+	var (
+		kind  = target.BasicType.Kind()
+		parse *jen.Statement
+	)
+	switch {
+	case isString(target):
+		if source.Named || target.Named {
+			return nil, xtype.OtherID(target.TypeAsJen().Call(valueID.Code.Clone())), nil
+		}
+		return nil, valueID, nil
+	case isInteger(target.BasicType) && isSigned(target.BasicType):
+		parse = jen.Qual("strconv", "ParseInt").Call(valueID.Code.Clone(), jen.Lit(10), jen.Lit(bitSize(kind)))
+		kind = types.Int64
+	case isInteger(target.BasicType):
+		parse = jen.Qual("strconv", "ParseUint").Call(valueID.Code.Clone(), jen.Lit(10), jen.Lit(bitSize(kind)))
+		kind = types.Uint64
+	case isFloat(target.BasicType):
+		parse = jen.Qual("strconv", "ParseFloat").Call(valueID.Code.Clone(), jen.Lit(bitSize(kind)))
+		kind = types.Float64
+	case kind == types.Bool:
+		parse = jen.Qual("strconv", "ParseBool").Call(valueID.Code.Clone())
+	default:
+		return gen.Build(ctx, valueID, source, target)
+	}
+
+	var (
+		name    = ctx.Name(target.ID())
+		errName = ctx.Name("err")
+	)
+	errID := jen.Qual("fmt", "Errorf").Call(jen.Lit(fmt.Sprintf("key %q: %%w", key)), jen.Id(errName))
+	ret, err := gen.ReturnError(ctx, fmt.Sprintf("the parsing of %s", target.T), errID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt := []jen.Code{
+		jen.List(jen.Id(name), jen.Id(errName)).Op(":=").Add(parse),
+		jen.If(jen.Id(errName).Op("!=").Nil()).Block(ret...),
+	}
+	id := jen.Id(name)
+	if target.Named || target.BasicType.Kind() != kind {
+		id = target.TypeAsJen().Call(id)
+	}
+	return stmt, xtype.OtherID(id), nil
+}
+
+// formatString formats basic values with strconv, other types are converted with the generator.
+func formatString(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	if !source.Basic || isEnum(source) {
+		return gen.Build(ctx, sourceID, source, target)
+	}
+
+	var (
+		kind   = source.BasicType.Kind()
+		format *jen.Statement
+	)
+	switch {
+	case isString(source):
+		if source.Named || target.Named {
+			return nil, xtype.OtherID(target.TypeAsJen().Call(sourceID.Code.Clone())), nil
+		}
+		return nil, sourceID, nil
+	case isInteger(source.BasicType) && isSigned(source.BasicType):
+		format = jen.Qual("strconv", "FormatInt").Call(castBasic(sourceID, source, types.Int64), jen.Lit(10))
+	case isInteger(source.BasicType):
+		format = jen.Qual("strconv", "FormatUint").Call(castBasic(sourceID, source, types.Uint64), jen.Lit(10))
+	case isFloat(source.BasicType):
+		format = jen.Qual("strconv", "FormatFloat").Call(castBasic(sourceID, source, types.Float64), jen.LitRune('g'), jen.Lit(-1), jen.Lit(bitSize(kind)))
+	case kind == types.Bool:
+		format = jen.Qual("strconv", "FormatBool").Call(castBasic(sourceID, source, types.Bool))
+	default:
+		return gen.Build(ctx, sourceID, source, target)
+	}
+
+	if target.Named {
+		format = target.TypeAsJen().Call(format)
+	}
+	return nil, xtype.OtherID(format), nil
+}
+
+// castBasic converts the sourceID into the basic kind, if the source has another type.
+func castBasic(sourceID *xtype.JenID, source *xtype.Type, kind types.BasicKind) *jen.Statement {
+	if !source.Named && source.BasicType.Kind() == kind {
+		return sourceID.Code.Clone()
+	}
+	return xtype.TypeOf(types.Typ[kind]).TypeAsJen().Call(sourceID.Code.Clone())
+}
+
+// bitSize returns the bit size argument of strconv, 0 is used for the platform dependent int and uint.
+func bitSize(kind types.BasicKind) int {
+	if size, ok := intSizes[kind]; ok {
+		if size[0] != size[1] {
+			return 0
+		}
+		return size[0] * 8
+	}
+	if kind == types.Float32 {
+		return 32
+	}
+	return 64
+}
+
+func isStringKeyMap(t *xtype.Type) bool {
+	return t.Map && isString(t.MapKey) && (isEmptyInterface(t.MapValue) || isString(t.MapValue))
+}
+
+func isEmptyInterface(t *xtype.Type) bool {
+	return t.Interface && t.InterfaceType.Empty()
+}
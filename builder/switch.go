@@ -0,0 +1,133 @@
+package builder
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// TypeSwitch handles interface source types with implementations declared via goverter:implementations.
+type TypeSwitch struct{}
+
+// Matches returns true, if the builder can create handle the given types.
+func (*TypeSwitch) Matches(source, target *xtype.Type, kind xtype.MethodKind) bool {
+	return source.Interface && kind == xtype.InSourceOutTarget && !types.AssignableTo(source.T, target.T)
+}
+
+// Build creates conversion source code for the given source and target type.
+func (*TypeSwitch) Build(gen Generator, ctx *MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *Error) {
+	impls, ok := ctx.Implementations[xtype.TypeString(source.T)]
+	if !ok {
+		if target.Interface {
+			return nil, nil, NewError(notImplementedError(source.T, target))
+		}
+		return nil, nil, NewError(missingImplementationsError(source, target))
+	}
+
+	var targetImpls []*xtype.Type
+	if target.Interface {
+		if t, ok := ctx.Implementations[xtype.TypeString(target.T)]; ok {
+			if len(t.Types) != len(impls.Types) {
+				cause := fmt.Sprintf("%s has %d implementations but %s has %d, the implementations are paired by their position",
+					source.T, len(impls.Types), target.T, len(t.Types))
+				return nil, nil, NewError(cause)
+			}
+			targetImpls = t.Types
+		}
+	}
+
+	var (
+		name  = ctx.Name(target.ID())
+		value = ctx.Name("value")
+		cases = []jen.Code{jen.Case(jen.Nil())}
+	)
+
+	for i, impl := range impls.Types {
+		nextTarget := target
+		ctx.TargetID = xtype.OtherID(jen.Id(name))
+		if targetImpls != nil {
+			nextTarget = targetImpls[i]
+			ctx.TargetID = nil
+		}
+		ctx.WantMethodKind = xtype.InSourceOutTarget
+
+		stmt, id, err := gen.Build(ctx, xtype.VariableID(jen.Id(value)), impl, nextTarget)
+		if err != nil {
+			return nil, nil, err.Lift(&Path{
+				SourceID:   ".(type)",
+				SourceType: impl.T.String(),
+				TargetType: nextTarget.T.String(),
+			})
+		}
+		if id != nil {
+			stmt = append(stmt, jen.Id(name).Op("=").Add(id.Code))
+		}
+
+		cases = append(cases, jen.Case(impl.TypeAsJen()).Block(stmt...))
+	}
+
+	var defaultStmt []jen.Code
+	if impls.Fallback != nil {
+		fallbackCtx := *ctx
+		fallbackCtx.TargetID = xtype.OtherID(jen.Id(name))
+		fallbackCtx.WantMethodKind = xtype.InSourceOutTarget
+		fallbackCtx.GlobalExtend = nil
+		fallbackCtx.MethodExtend = map[xtype.Signature]*MethodDefinition{
+			{
+				Source: xtype.TypeString(impls.Fallback.Source.T),
+				Target: xtype.TypeString(impls.Fallback.Target.T),
+				Kind:   impls.Fallback.Kind,
+			}: impls.Fallback,
+		}
+
+		ok, stmt, id, err := gen.BuildWithExtend(&fallbackCtx, xtype.VariableID(jen.Id(value)), source, target)
+		if !ok {
+			cause := fmt.Sprintf("Fallback %s cannot convert %s to %s", impls.Fallback.ID, source.T, target.T)
+			return nil, nil, NewError(cause)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		defaultStmt = stmt
+		if id != nil {
+			defaultStmt = append(defaultStmt, jen.Id(name).Op("=").Add(id.Code))
+		}
+	} else {
+		errID := jen.Qual("fmt", "Errorf").Call(
+			jen.Lit(fmt.Sprintf("unsupported implementation %%T of %s", source.T)),
+			jen.Id(value),
+		)
+
+		ret, err := gen.ReturnError(ctx, "the default branch of the type switch on "+source.T.String(), errID)
+		if err != nil {
+			return nil, nil, err
+		}
+		defaultStmt = ret
+	}
+	cases = append(cases, jen.Default().Block(defaultStmt...))
+
+	stmt := []jen.Code{
+		jen.Var().Id(name).Add(target.TypeAsJen()),
+		jen.Switch(jen.Id(value).Op(":=").Add(sourceID.Code.Clone()).Assert(jen.Type())).Block(cases...),
+	}
+
+	return stmt, xtype.VariableID(jen.Id(name)), nil
+}
+
+func missingImplementationsError(source, target *xtype.Type) string {
+	name := source.T.String()
+	if source.Named {
+		name = source.NamedType.Obj().Name()
+	}
+
+	return fmt.Sprintf(`TypeMismatch: Cannot convert %s to %s
+
+The source is an interface, declare its implementations to generate a type switch:
+
+    goverter:implementations %s Impl1 Impl2
+
+See https://github.com/pengdaCN/goverter/blob/main/addition.md`, source.T, target.T, name)
+}
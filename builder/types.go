@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"go/types"
+
 	"github.com/dave/jennifer/jen"
 	"github.com/pengdaCN/goverter/xtype"
 )
@@ -20,4 +22,19 @@ type MethodDefinition struct {
 	ReturnError      bool
 	ReturnTypeOrigin string
 	Dirty            bool
+	// Ctx is the context of the method, that created this method. It is reused if the method
+	// has to be rebuilt.
+	Ctx *MethodContext
+
+	// Signature is set for generic extend methods, they are instantiated with the types of the conversion.
+	Signature *types.Signature
+	// Callback is the conversion function passed as second parameter to a generic extend method like
+	// MapSlice[S, T any](in []S, f func(S) T) []T.
+	Callback *MethodDefinition
+}
+
+// Implementations contains the implementations of an interface declared with goverter:implementations.
+type Implementations struct {
+	Types    []*xtype.Type
+	Fallback *MethodDefinition
 }
@@ -16,6 +16,7 @@ func main() {
 	output := flag.String("output", "./generated/generated.go", "")
 	extends := flag.String("extends", "", "comma separated list of local or package extends")
 	packagePath := flag.String("packagePath", "", "optional full package path for the generated code")
+	preserveNilCollections := flag.Bool("preserveNilCollections", false, "convert nil slices and maps into nil instead of empty ones")
 	showVersion := flag.Bool("version", false, "print program version")
 
 	flag.Parse()
@@ -37,10 +38,11 @@ func main() {
 	}
 
 	err := goverter.GenerateConverterFile(*output, goverter.GenerateConfig{
-		PackageName:   *packageName,
-		ScanDir:       pattern,
-		ExtendMethods: extendMethods,
-		PackagePath:   *packagePath,
+		PackageName:            *packageName,
+		ScanDir:                pattern,
+		ExtendMethods:          extendMethods,
+		PackagePath:            *packagePath,
+		PreserveNilCollections: *preserveNilCollections,
 	})
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
@@ -39,6 +39,26 @@ type Converter struct {
 	Scope          *types.Scope
 	globalExtend   map[xtype.Signature]*builder.MethodDefinition
 	specificExtend map[string]map[xtype.Signature]*builder.MethodDefinition
+
+	globalImplementations   map[string]*builder.Implementations
+	specificImplementations map[string]map[string]*builder.Implementations
+
+	optionals map[string]*builder.Optional
+}
+
+// Implementations contains the declared implementations of an interface type.
+type Implementations struct {
+	Types    []string
+	Fallback string
+}
+
+// Optional contains the declaration of a generic wrapper type.
+type Optional struct {
+	Type   string
+	Get    string
+	IsSome string
+	Some   string
+	None   string
 }
 
 // ConverterConfig contains settings that can be set via comments.
@@ -48,6 +68,19 @@ type ConverterConfig struct {
 	NoStrict         bool
 	IgnoreUnexported bool
 	UseTag           []string
+	Implementations  map[string]*Implementations
+	ArrayLength      builder.ArrayLength
+	NumericNarrowing builder.NumericNarrowing
+	CopyBytes        bool
+	NilPolicy        builder.NilPolicy
+	CopySameType     builder.CopySameType
+	// NilCollections is nil, if the converter uses the global setting.
+	NilCollections *builder.NilCollections
+	Enum           bool
+	EnumPolicy     builder.EnumPolicy
+	EnumMapping    map[string]string
+	EnumTrimPrefix string
+	Optionals      []Optional
 }
 
 // Method contains settings that can be set via comments.
@@ -64,6 +97,18 @@ type Method struct {
 	ExtendMethods         []string
 	IgnoreTag             bool
 	Tag                   []string
+	Implementations       map[string]*Implementations
+	ArrayLength           *builder.ArrayLength
+	NumericNarrowing      *builder.NumericNarrowing
+	CopyBytes             bool
+	NilPolicy             *builder.NilPolicy
+	CopySameType          *builder.CopySameType
+	NilCollections        *builder.NilCollections
+	Enum                  bool
+	EnumPolicy            *builder.EnumPolicy
+	EnumMapping           map[string]string
+	EnumTrimPrefix        string
+	MapKeys               map[string]*builder.MapKey
 }
 
 func (c *Converter) BuildCtx(method string) *builder.MethodContext {
@@ -87,15 +132,77 @@ func (c *Converter) BuildCtx(method string) *builder.MethodContext {
 		}
 	}
 
+	arrayLength := c.Config.ArrayLength
+	if m.ArrayLength != nil {
+		arrayLength = *m.ArrayLength
+	}
+
+	numericNarrowing := c.Config.NumericNarrowing
+	if m.NumericNarrowing != nil {
+		numericNarrowing = *m.NumericNarrowing
+	}
+
+	nilPolicy := c.Config.NilPolicy
+	if m.NilPolicy != nil {
+		nilPolicy = *m.NilPolicy
+	}
+
+	copySameType := c.Config.CopySameType
+	if m.CopySameType != nil {
+		copySameType = *m.CopySameType
+	}
+
+	var nilCollections builder.NilCollections
+	if c.Config.NilCollections != nil {
+		nilCollections = *c.Config.NilCollections
+	}
+	if m.NilCollections != nil {
+		nilCollections = *m.NilCollections
+	}
+
+	enumPolicy := c.Config.EnumPolicy
+	if m.EnumPolicy != nil {
+		enumPolicy = *m.EnumPolicy
+	}
+
+	enumMapping := c.Config.EnumMapping
+	if len(m.EnumMapping) != 0 {
+		enumMapping = make(map[string]string, len(c.Config.EnumMapping)+len(m.EnumMapping))
+		for source, target := range c.Config.EnumMapping {
+			enumMapping[source] = target
+		}
+		for source, target := range m.EnumMapping {
+			enumMapping[source] = target
+		}
+	}
+
+	enumTrimPrefix := c.Config.EnumTrimPrefix
+	if m.EnumTrimPrefix != "" {
+		enumTrimPrefix = m.EnumTrimPrefix
+	}
+
 	if !ok {
 		return &builder.MethodContext{
-			GlobalExtend: c.getGlobalExtend(),
+			GlobalExtend:     c.getGlobalExtend(),
+			Implementations:  c.globalImplementations,
+			ArrayLength:      arrayLength,
+			NumericNarrowing: numericNarrowing,
+			CopyBytes:        c.Config.CopyBytes,
+			NilPolicy:        nilPolicy,
+			CopySameType:     copySameType,
+			NilCollections:   nilCollections,
+			Enum:             c.Config.Enum,
+			EnumPolicy:       enumPolicy,
+			EnumMapping:      enumMapping,
+			EnumTrimPrefix:   enumTrimPrefix,
+			Optionals:        c.optionals,
 		}
 	}
 
 	return &builder.MethodContext{
 		GlobalExtend:     c.globalExtend,
 		MethodExtend:     c.getSpecificExtend(method),
+		Implementations:  c.getImplementations(method),
 		SearchTag:        tag,
 		Mapping:          m.NameMapping,
 		MatchIgnoreCase:  m.MatchIgnoreCase,
@@ -103,6 +210,18 @@ func (c *Converter) BuildCtx(method string) *builder.MethodContext {
 		IdentityMapping:  m.IdentityMapping,
 		NoStrict:         noStrict,
 		IgnoreUnexported: ignoreUnexported,
+		ArrayLength:      arrayLength,
+		NumericNarrowing: numericNarrowing,
+		CopyBytes:        c.Config.CopyBytes || m.CopyBytes,
+		NilPolicy:        nilPolicy,
+		CopySameType:     copySameType,
+		NilCollections:   nilCollections,
+		Enum:             c.Config.Enum || m.Enum,
+		EnumPolicy:       enumPolicy,
+		EnumMapping:      enumMapping,
+		EnumTrimPrefix:   enumTrimPrefix,
+		MapKeys:          m.MapKeys,
+		Optionals:        c.optionals,
 		ID:               method,
 	}
 }
@@ -140,11 +259,45 @@ func (c *Converter) RegSpecificExtend(method string, extend map[xtype.Signature]
 	c.specificExtend[method] = extend
 }
 
+// getImplementations merges the implementations declared on the method into the global ones.
+func (c *Converter) getImplementations(method string) map[string]*builder.Implementations {
+	specific, ok := c.specificImplementations[method]
+	if !ok {
+		return c.globalImplementations
+	}
+
+	implementations := make(map[string]*builder.Implementations, len(c.globalImplementations)+len(specific))
+	for inter, impl := range c.globalImplementations {
+		implementations[inter] = impl
+	}
+	for inter, impl := range specific {
+		implementations[inter] = impl
+	}
+
+	return implementations
+}
+
+func (c *Converter) RegGlobalImplementations(implementations map[string]*builder.Implementations) {
+	c.globalImplementations = implementations
+}
+
+func (c *Converter) RegSpecificImplementations(method string, implementations map[string]*builder.Implementations) {
+	if c.specificImplementations == nil {
+		c.specificImplementations = make(map[string]map[string]*builder.Implementations)
+	}
+
+	c.specificImplementations[method] = implementations
+}
+
+func (c *Converter) RegOptionals(optionals map[string]*builder.Optional) {
+	c.optionals = optionals
+}
+
 // ParseDocs parses the docs for the given pattern.
 func ParseDocs(config ParseDocsConfig) ([]Converter, error) {
 	loadCfg := &packages.Config{
 		Mode: packages.NeedSyntax | packages.NeedCompiledGoFiles | packages.NeedTypes |
-			packages.NeedModule | packages.NeedFiles | packages.NeedName | packages.NeedImports,
+			packages.NeedModule | packages.NeedFiles | packages.NeedName | packages.NeedImports | packages.NeedDeps,
 		Dir: config.WorkingDir,
 	}
 	pkgs, err := packages.Load(loadCfg, config.PackagePattern)
@@ -290,6 +443,96 @@ func parseConverterComment(comment string, config ConverterConfig) (ConverterCon
 
 				config.UseTag = fields
 				continue
+			case "implementations":
+				if len(fields) < 3 {
+					return config, fmt.Errorf("invalid %s:implementations must have an interface and at least one implementation", prefix)
+				}
+
+				config.Implementations = addImplementations(config.Implementations, fields[1], fields[2:]...)
+				continue
+			case "implementationsFallback":
+				if len(fields) != 3 {
+					return config, fmt.Errorf("invalid %s:implementationsFallback must have two parameter", prefix)
+				}
+
+				config.Implementations = addImplementationsFallback(config.Implementations, fields[1], fields[2])
+				continue
+			case "arrayLength":
+				arrayLength, err := parseArrayLength(fields)
+				if err != nil {
+					return config, err
+				}
+
+				config.ArrayLength = arrayLength
+				continue
+			case "numericNarrowing":
+				numericNarrowing, err := parseNumericNarrowing(fields)
+				if err != nil {
+					return config, err
+				}
+
+				config.NumericNarrowing = numericNarrowing
+				continue
+			case "copyBytes":
+				config.CopyBytes = true
+				continue
+			case "nilPolicy":
+				nilPolicy, err := parseNilPolicy(fields)
+				if err != nil {
+					return config, err
+				}
+
+				config.NilPolicy = nilPolicy
+				continue
+			case "copySameType":
+				copySameType, err := parseCopySameType(fields)
+				if err != nil {
+					return config, err
+				}
+
+				config.CopySameType = copySameType
+				continue
+			case "nilCollections":
+				nilCollections, err := parseNilCollections(fields)
+				if err != nil {
+					return config, err
+				}
+
+				config.NilCollections = &nilCollections
+				continue
+			case "enum":
+				enumPolicy, err := parseEnum(fields)
+				if err != nil {
+					return config, err
+				}
+
+				config.Enum = true
+				if enumPolicy != nil {
+					config.EnumPolicy = *enumPolicy
+				}
+				continue
+			case "enumMap":
+				if len(fields) != 3 {
+					return config, fmt.Errorf("invalid %s:enumMap must have two parameter", prefix)
+				}
+
+				config.EnumMapping = addEnumMapping(config.EnumMapping, fields[1], fields[2])
+				continue
+			case "enumTrimPrefix":
+				if len(fields) != 2 {
+					return config, fmt.Errorf("invalid %s:enumTrimPrefix must have one parameter", prefix)
+				}
+
+				config.EnumTrimPrefix = fields[1]
+				continue
+			case "optional":
+				optional, err := parseOptional(fields)
+				if err != nil {
+					return config, err
+				}
+
+				config.Optionals = append(config.Optionals, optional)
+				continue
 			}
 			return config, fmt.Errorf("unknown %s comment: %s", prefix, line)
 		}
@@ -360,9 +603,270 @@ func parseMethodComment(comment string) (Method, error) {
 
 				m.Tag = fields
 				continue
+			case "implementations":
+				if len(fields) < 3 {
+					return m, fmt.Errorf("invalid %s:implementations must have an interface and at least one implementation", prefix)
+				}
+
+				m.Implementations = addImplementations(m.Implementations, fields[1], fields[2:]...)
+				continue
+			case "implementationsFallback":
+				if len(fields) != 3 {
+					return m, fmt.Errorf("invalid %s:implementationsFallback must have two parameter", prefix)
+				}
+
+				m.Implementations = addImplementationsFallback(m.Implementations, fields[1], fields[2])
+				continue
+			case "arrayLength":
+				arrayLength, err := parseArrayLength(fields)
+				if err != nil {
+					return m, err
+				}
+
+				m.ArrayLength = &arrayLength
+				continue
+			case "numericNarrowing":
+				numericNarrowing, err := parseNumericNarrowing(fields)
+				if err != nil {
+					return m, err
+				}
+
+				m.NumericNarrowing = &numericNarrowing
+				continue
+			case "copyBytes":
+				m.CopyBytes = true
+				continue
+			case "nilPolicy":
+				nilPolicy, err := parseNilPolicy(fields)
+				if err != nil {
+					return m, err
+				}
+
+				m.NilPolicy = &nilPolicy
+				continue
+			case "copySameType":
+				copySameType, err := parseCopySameType(fields)
+				if err != nil {
+					return m, err
+				}
+
+				m.CopySameType = &copySameType
+				continue
+			case "nilCollections":
+				nilCollections, err := parseNilCollections(fields)
+				if err != nil {
+					return m, err
+				}
+
+				m.NilCollections = &nilCollections
+				continue
+			case "enum":
+				enumPolicy, err := parseEnum(fields)
+				if err != nil {
+					return m, err
+				}
+
+				m.Enum = true
+				if enumPolicy != nil {
+					m.EnumPolicy = enumPolicy
+				}
+				continue
+			case "enumMap":
+				if len(fields) != 3 {
+					return m, fmt.Errorf("invalid %s:enumMap must have two parameter", prefix)
+				}
+
+				m.EnumMapping = addEnumMapping(m.EnumMapping, fields[1], fields[2])
+				continue
+			case "enumTrimPrefix":
+				if len(fields) != 2 {
+					return m, fmt.Errorf("invalid %s:enumTrimPrefix must have one parameter", prefix)
+				}
+
+				m.EnumTrimPrefix = fields[1]
+				continue
+			case "mapKey":
+				if len(fields) != 2 && len(fields) != 3 {
+					return m, fmt.Errorf("invalid %s:mapKey must have the key field and optionally the target field as first parameter", prefix)
+				}
+
+				field, key := "", fields[1]
+				if len(fields) == 3 {
+					field, key = fields[1], fields[2]
+				}
+
+				m.MapKeys = addMapKey(m.MapKeys, field)
+				m.MapKeys[field].Field = key
+				continue
+			case "mapKeySorted":
+				if len(fields) > 2 {
+					return m, fmt.Errorf("invalid %s:mapKeySorted must have at most one parameter", prefix)
+				}
+
+				var field string
+				if len(fields) == 2 {
+					field = fields[1]
+				}
+
+				m.MapKeys = addMapKey(m.MapKeys, field)
+				m.MapKeys[field].Sorted = true
+				continue
 			}
 			return m, fmt.Errorf("unknown %s comment: %s", prefix, line)
 		}
 	}
 	return m, nil
 }
+
+func addImplementations(m map[string]*Implementations, inter string, impls ...string) map[string]*Implementations {
+	if m == nil {
+		m = make(map[string]*Implementations)
+	}
+	if _, ok := m[inter]; !ok {
+		m[inter] = &Implementations{}
+	}
+
+	m[inter].Types = append(m[inter].Types, impls...)
+	return m
+}
+
+func addImplementationsFallback(m map[string]*Implementations, inter, fallback string) map[string]*Implementations {
+	if m == nil {
+		m = make(map[string]*Implementations)
+	}
+	if _, ok := m[inter]; !ok {
+		m[inter] = &Implementations{}
+	}
+
+	m[inter].Fallback = fallback
+	return m
+}
+
+func parseArrayLength(fields []string) (builder.ArrayLength, error) {
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid %s:arrayLength must have one parameter", prefix)
+	}
+
+	switch fields[1] {
+	case "error":
+		return builder.ArrayLengthError, nil
+	case "truncate":
+		return builder.ArrayLengthTruncate, nil
+	}
+	return 0, fmt.Errorf("invalid %s:arrayLength %s, expected error or truncate", prefix, fields[1])
+}
+
+func parseNumericNarrowing(fields []string) (builder.NumericNarrowing, error) {
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid %s:numericNarrowing must have one parameter", prefix)
+	}
+
+	switch fields[1] {
+	case "checked":
+		return builder.NumericNarrowingChecked, nil
+	case "truncate":
+		return builder.NumericNarrowingTruncate, nil
+	}
+	return 0, fmt.Errorf("invalid %s:numericNarrowing %s, expected checked or truncate", prefix, fields[1])
+}
+
+func parseNilPolicy(fields []string) (builder.NilPolicy, error) {
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid %s:nilPolicy must have one parameter", prefix)
+	}
+
+	switch fields[1] {
+	case "zero":
+		return builder.NilPolicyZero, nil
+	case "error":
+		return builder.NilPolicyError, nil
+	case "skip":
+		return builder.NilPolicySkip, nil
+	}
+	return 0, fmt.Errorf("invalid %s:nilPolicy %s, expected zero, error or skip", prefix, fields[1])
+}
+
+func parseCopySameType(fields []string) (builder.CopySameType, error) {
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid %s:copySameType must have one parameter", prefix)
+	}
+
+	switch fields[1] {
+	case "deep":
+		return builder.CopySameTypeDeep, nil
+	case "shallow":
+		return builder.CopySameTypeShallow, nil
+	}
+	return 0, fmt.Errorf("invalid %s:copySameType %s, expected shallow or deep", prefix, fields[1])
+}
+
+func parseNilCollections(fields []string) (builder.NilCollections, error) {
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid %s:nilCollections must have one parameter", prefix)
+	}
+
+	switch fields[1] {
+	case "empty":
+		return builder.NilCollectionsEmpty, nil
+	case "preserve":
+		return builder.NilCollectionsPreserve, nil
+	}
+	return 0, fmt.Errorf("invalid %s:nilCollections %s, expected preserve or empty", prefix, fields[1])
+}
+
+func parseEnum(fields []string) (*builder.EnumPolicy, error) {
+	if len(fields) > 2 {
+		return nil, fmt.Errorf("invalid %s:enum must have at most one parameter", prefix)
+	}
+	if len(fields) == 1 {
+		return nil, nil
+	}
+
+	var enumPolicy builder.EnumPolicy
+	switch fields[1] {
+	case "zero":
+		enumPolicy = builder.EnumPolicyZero
+	case "error":
+		enumPolicy = builder.EnumPolicyError
+	default:
+		return nil, fmt.Errorf("invalid %s:enum %s, expected zero or error", prefix, fields[1])
+	}
+	return &enumPolicy, nil
+}
+
+func addEnumMapping(m map[string]string, source, target string) map[string]string {
+	if m == nil {
+		m = make(map[string]string)
+	}
+
+	m[source] = target
+	return m
+}
+
+func addMapKey(m map[string]*builder.MapKey, field string) map[string]*builder.MapKey {
+	if m == nil {
+		m = make(map[string]*builder.MapKey)
+	}
+	if _, ok := m[field]; !ok {
+		m[field] = &builder.MapKey{}
+	}
+
+	return m
+}
+
+func parseOptional(fields []string) (Optional, error) {
+	if len(fields) != 5 && len(fields) != 6 {
+		return Optional{}, fmt.Errorf("invalid %s:optional must have the parameters type, get, isSome, some and optionally none", prefix)
+	}
+
+	optional := Optional{
+		Type:   fields[1],
+		Get:    fields[2],
+		IsSome: fields[3],
+		Some:   fields[4],
+	}
+	if len(fields) == 6 {
+		optional.None = fields[5]
+	}
+	return optional, nil
+}
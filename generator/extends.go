@@ -18,6 +18,10 @@ const (
 	// packageNameSep separates between package path and name pattern
 	// in goverter:extend input with package path.
 	packageNameSep = ":"
+	// stdExtend is a shorthand for extending the conversions of the stdconv package.
+	stdExtend = "std"
+	// stdconvPattern loads all conversions of the stdconv package.
+	stdconvPattern = "github.com/pengdaCN/goverter/stdconv" + packageNameSep + ".*"
 )
 
 type parseExtendContext struct {
@@ -146,6 +150,9 @@ func (g *parseExtendContext) searchExtendsInScope(scope *types.Scope, opts *Pars
 func (g *parseExtendContext) parseExtend(converterInterface types.Type, converterScope *types.Scope, methods []string) (map[xtype.Signature]*builder.MethodDefinition, error) {
 	extend := make(map[xtype.Signature]*builder.MethodDefinition)
 	for _, methodName := range methods {
+		if methodName == stdExtend {
+			methodName = stdconvPattern
+		}
 		parts := strings.SplitN(methodName, packageNameSep, 2)
 		var pkgPath, namePattern string
 		switch len(parts) {
@@ -218,8 +225,8 @@ func (g *parseExtendContext) parseExtendFunc(fn *types.Func, opts *ParseExtendOp
 		return err
 	}
 	xsig := xtype.Signature{
-		Source: m.Source.T.String(),
-		Target: m.Target.T.String(),
+		Source: xtype.TypeString(m.Source.T),
+		Target: xtype.TypeString(m.Target.T),
 		Kind:   m.Kind,
 	}
 
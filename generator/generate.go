@@ -17,18 +17,41 @@ type Config struct {
 	PackagePath   string
 	ExtendMethods []string
 	WorkingDir    string
+	// PreserveNilCollections is used by converters without goverter:nilCollections.
+	PreserveNilCollections bool
+}
+
+// DirectSteps convert the types without a method, they're checked before a method is created.
+var DirectSteps = []builder.Builder{
+	&builder.SameType{},
+	&builder.Convertible{},
 }
 
 // BuildSteps that'll used for generation.
 var BuildSteps = []builder.Builder{
+	&builder.OptionalWrapper{},
+	&builder.SQLNull{},
 	&builder.ZeroCopyStruct{},
 	&builder.BasicTargetPointerRule{},
 	&builder.Struct{},
 	&builder.TargetStruct{},
 	&builder.Pointer{},
 	&builder.TargetPointer{},
+	&builder.SourcePointer{},
+	&builder.Enum{},
+	&builder.EnumString{},
 	&builder.Basic{},
+	&builder.Numeric{},
+	&builder.FuncChan{},
+	&builder.TypeSwitch{},
+	&builder.Interface{},
+	&builder.String{},
+	&builder.Array{},
 	&builder.List{},
+	&builder.StructToMap{},
+	&builder.MapToStruct{},
+	&builder.ListToMap{},
+	&builder.MapToList{},
 	&builder.Map{},
 }
 
@@ -43,16 +66,27 @@ func Generate(pattern string, mapping []comments.Converter, config Config) (*jen
 			return nil, fmt.Errorf("%s: could not find %s", pattern, converter.Name)
 		}
 
-		// create the converter struct
-		file.Add(jen.Comment("nolint"))
-		file.Type().Id(converter.Config.Name).Struct()
-
 		gen := generator{
 			namer:  namer.New(),
 			file:   file,
 			name:   converter.Config.Name,
 			lookup: make(map[xtype.Signature]*builder.MethodDefinition),
 		}
+
+		// create the converter struct, a generic converter interface gets a generic implementation
+		implType := jen.Id(converter.Config.Name)
+		if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() != 0 {
+			params := named.TypeParams()
+			decl := make([]jen.Code, 0, params.Len())
+			for i := 0; i < params.Len(); i++ {
+				param := params.At(i)
+				decl = append(decl, jen.Id(param.Obj().Name()).Add(xtype.TypeOf(param.Constraint()).TypeAsJen()))
+				gen.typeParams = append(gen.typeParams, jen.Id(param.Obj().Name()))
+			}
+			implType = implType.Types(decl...)
+		}
+		file.Add(jen.Comment("nolint"))
+		file.Type().Add(implType).Struct()
 		interf := obj.Type().Underlying().(*types.Interface)
 
 		extendMethods := make([]string, 0, len(config.ExtendMethods)+len(converter.Config.ExtendMethods))
@@ -69,6 +103,23 @@ func Generate(pattern string, mapping []comments.Converter, config Config) (*jen
 		}
 		converter.RegGlobalExtend(extend)
 
+		implementations, err := parseExtendCtx.parseImplementations(obj.Type(), converter.Scope, converter.Config.Implementations)
+		if err != nil {
+			return nil, fmt.Errorf("Error while parsing implementations in\n    %s\n\n%s", obj.Type().String(), err)
+		}
+		converter.RegGlobalImplementations(implementations)
+
+		optionals, err := parseExtendCtx.parseOptionals(converter.Scope, converter.Config.Optionals)
+		if err != nil {
+			return nil, fmt.Errorf("Error while parsing optionals in\n    %s\n\n%s", obj.Type().String(), err)
+		}
+		converter.RegOptionals(optionals)
+
+		if converter.Config.NilCollections == nil && config.PreserveNilCollections {
+			nilCollections := builder.NilCollectionsPreserve
+			converter.Config.NilCollections = &nilCollections
+		}
+
 		// we checked in comments, that it is an interface
 		for i := 0; i < interf.NumMethods(); i++ {
 			method := interf.Method(i)
@@ -87,6 +138,15 @@ func Generate(pattern string, mapping []comments.Converter, config Config) (*jen
 				converter.RegSpecificExtend(method.Name(), localExtend)
 			}
 
+			if len(m.Implementations) != 0 {
+				localImplementations, err := parseExtendCtx.parseImplementations(obj.Type(), converter.Scope, inheritImplementations(converter.Config.Implementations, m.Implementations))
+				if err != nil {
+					return nil, fmt.Errorf("Error while parsing implementations in\n    %s\n\n%s", method.Name(), err)
+				}
+
+				converter.RegSpecificImplementations(method.Name(), localImplementations)
+			}
+
 			if err := gen.registerMethod(method); err != nil {
 				return nil, fmt.Errorf("Error while creating converter method:\n    %s\n\n%s", method.String(), err)
 			}
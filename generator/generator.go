@@ -20,6 +20,8 @@ type generator struct {
 	name   string
 	file   *jen.File
 	lookup map[xtype.Signature]*builder.MethodDefinition
+	// typeParams of a generic converter interface, they are used as type arguments of the receiver.
+	typeParams []jen.Code
 }
 
 func (g *generator) registerMethod(methodType *types.Func) error {
@@ -30,8 +32,8 @@ func (g *generator) registerMethod(methodType *types.Func) error {
 	m.Explicit = true
 
 	g.lookup[xtype.Signature{
-		Source: m.Source.T.String(),
-		Target: m.Target.T.String(),
+		Source: xtype.TypeString(m.Source.T),
+		Target: xtype.TypeString(m.Target.T),
 		Kind:   m.Kind,
 	}] = m
 	g.namer.Register(m.Name)
@@ -53,6 +55,9 @@ func (g *generator) createMethods(doc *comments.Converter) error {
 		method.Dirty = false
 
 		ctx := doc.BuildCtx(method.Name)
+		if method.Ctx != nil {
+			ctx = method.Ctx
+		}
 
 		err := g.buildMethod(ctx.Enter(), method)
 		if err != nil {
@@ -111,7 +116,10 @@ func (g *generator) buildMethod(ctx *builder.MethodContext, method *builder.Meth
 	if method.Kind == xtype.InSourceIn2Target {
 		ctx.TargetID = xtype.VariableID(targetID.Clone())
 	}
-	ctx.Signature = xtype.Signature{Source: method.Source.T.String(), Target: method.Target.T.String(), Kind: method.Kind}
+	ctx.Signature = xtype.Signature{Source: xtype.TypeString(method.Source.T), Target: xtype.TypeString(method.Target.T), Kind: method.Kind}
+	if !method.Explicit {
+		ctx.Signature.Settings = ctx.Settings()
+	}
 	ctx.WantMethodKind = ctx.Signature.Kind
 
 	stmt, newID, err := g.buildNoLookup(ctx, xtype.VariableID(sourceID.Clone()), source, target)
@@ -146,7 +154,7 @@ func (g *generator) buildMethod(ctx *builder.MethodContext, method *builder.Meth
 		Params(
 			jen.Id(xtype.ThisVar).
 				Op("*").
-				Id(g.name),
+				Add(g.receiver()),
 		).
 		Id(method.Name).
 		Params(params...).
@@ -156,9 +164,28 @@ func (g *generator) buildMethod(ctx *builder.MethodContext, method *builder.Meth
 	return nil
 }
 
+// receiver returns the type of the converter implementation.
+func (g *generator) receiver() *jen.Statement {
+	if len(g.typeParams) == 0 {
+		return jen.Id(g.name)
+	}
+	return jen.Id(g.name).Types(g.typeParams...)
+}
+
 func (g *generator) buildNoLookup(ctx *builder.MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *builder.Error) {
+	if err := supported(source, target); err != nil {
+		return nil, nil, err
+	}
+	if source.TypeParam || target.TypeParam {
+		return buildTypeParam(sourceID, source, target)
+	}
+	for _, rule := range DirectSteps {
+		if matches(rule, ctx, source, target) {
+			return rule.Build(g, ctx, sourceID, source, target)
+		}
+	}
 	for _, rule := range BuildSteps {
-		if rule.Matches(source, target, ctx.WantMethodKind) {
+		if matches(rule, ctx, source, target) {
 			return rule.Build(g, ctx, sourceID, source, target)
 		}
 	}
@@ -172,33 +199,28 @@ func (g *generator) Build(ctx *builder.MethodContext, sourceID *xtype.JenID, sou
 		return codes, id, err
 	}
 
-	if (source.Named && !source.Basic) || (target.Named && !target.Basic) || (source.Pointer && target.Pointer && source.PointerInner.Struct && target.PointerInner.Struct) {
-		var name string
+	if err := supported(source, target); err != nil {
+		return nil, nil, err
+	}
 
-		m := &builder.MethodDefinition{
-			Source: xtype.TypeOf(source.T),
-			Target: xtype.TypeOf(target.T),
-		}
+	if source.TypeParam || target.TypeParam {
+		return buildTypeParam(sourceID, source, target)
+	}
 
-		if source.Pointer && target.Pointer && source.PointerInner.Struct && target.PointerInner.Struct {
-			m.Kind = xtype.InSourceIn2Target
+	for _, rule := range DirectSteps {
+		if matches(rule, ctx, source, target) {
+			return rule.Build(g, ctx, sourceID, source, target)
 		}
+	}
 
-		switch m.Kind {
-		case xtype.InSourceOutTarget:
-			name = g.namer.Name(source.UnescapedID() + "To" + cases.Title(language.English).String(target.UnescapedID()))
-		case xtype.InSourceIn2Target:
-			name = g.namer.Name(source.UnescapedID() + "Mapping" + cases.Title(language.English).String(target.UnescapedID()))
+	if !target.Interface && ((source.Named && !source.Basic) || (target.Named && !target.Basic)) || (source.Pointer && target.Pointer && source.PointerInner.Struct && target.PointerInner.Struct) {
+		kind := xtype.InSourceOutTarget
+		// the retry below looks up the method with the wanted kind
+		if source.Pointer && target.Pointer && source.PointerInner.Struct && target.PointerInner.Struct && ctx.WantMethodKind == xtype.InSourceIn2Target {
+			kind = xtype.InSourceIn2Target
 		}
 
-		m.ID = name
-		m.Name = name
-		m.Call = jen.Id(xtype.ThisVar).Dot(name)
-
-		g.lookup[xtype.Signature{Source: source.T.String(), Target: target.T.String(), Kind: m.Kind}] = m
-
-		g.namer.Register(m.Name)
-		if err := g.buildMethod(ctx.Enter(), m); err != nil {
+		if _, err := g.createMethod(ctx, source, target, kind); err != nil {
 			return nil, nil, err
 		}
 		// try again to trigger the found method thingy above
@@ -206,7 +228,7 @@ func (g *generator) Build(ctx *builder.MethodContext, sourceID *xtype.JenID, sou
 	}
 
 	for _, rule := range BuildSteps {
-		if rule.Matches(source, target, ctx.WantMethodKind) {
+		if matches(rule, ctx, source, target) {
 			return rule.Build(g, ctx, sourceID, source, target)
 		}
 	}
@@ -214,6 +236,74 @@ func (g *generator) Build(ctx *builder.MethodContext, sourceID *xtype.JenID, sou
 	return nil, nil, builder.NewError(fmt.Sprintf("TypeMismatch: Cannot convert %s to %s", source.T, target.T))
 }
 
+// createMethod creates and builds a new converter method for the given source and target type.
+func (g *generator) createMethod(ctx *builder.MethodContext, source, target *xtype.Type, kind xtype.MethodKind) (*builder.MethodDefinition, *builder.Error) {
+	var name string
+
+	m := &builder.MethodDefinition{
+		Source: xtype.TypeOf(source.T),
+		Target: xtype.TypeOf(target.T),
+		Kind:   kind,
+	}
+
+	switch m.Kind {
+	case xtype.InSourceOutTarget:
+		name = g.namer.Name(source.UnescapedID() + "To" + cases.Title(language.English).String(target.UnescapedID()))
+	case xtype.InSourceIn2Target:
+		name = g.namer.Name(source.UnescapedID() + "Mapping" + cases.Title(language.English).String(target.UnescapedID()))
+	}
+
+	m.ID = name
+	m.Name = name
+	m.Call = jen.Id(xtype.ThisVar).Dot(name)
+	// the caller keeps changing its context, the field path starts at the source of the new method
+	m.Ctx = ctx.Enter()
+	m.Ctx.FieldPath = ""
+
+	g.lookup[xtype.Signature{Source: xtype.TypeString(source.T), Target: xtype.TypeString(target.T), Kind: m.Kind, Settings: ctx.Settings()}] = m
+
+	g.namer.Register(m.Name)
+	if err := g.buildMethod(m.Ctx.Enter(), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// callback returns the function passed as conversion function to a generic extend method, it's either an
+// extend method, a converter method or a newly created method.
+func (g *generator) callback(ctx *builder.MethodContext, callback *builder.MethodDefinition) (*jen.Statement, *builder.Error) {
+	source, target := callback.Source, callback.Target
+
+	var method *builder.MethodDefinition
+	for _, extends := range []map[xtype.Signature]*builder.MethodDefinition{ctx.MethodExtend, ctx.GlobalExtend} {
+		if m, ok := lookupExtendKind(extends, xtype.InSourceOutTarget, source.T, target.T, false); ok && !m.SelfAsFirstParam {
+			method = m
+			break
+		}
+	}
+	if method == nil {
+		m, ok := g._lookup(ctx, source, target, xtype.InSourceOutTarget)
+		if !ok {
+			var err *builder.Error
+			m, err = g.createMethod(ctx, source, target, xtype.InSourceOutTarget)
+			if err != nil {
+				return nil, err.Lift(&builder.Path{
+					SourceID:   "func",
+					SourceType: source.T.String(),
+					TargetID:   "func",
+					TargetType: target.T.String(),
+				})
+			}
+		}
+		method = m
+	}
+
+	if method.ReturnError {
+		return nil, builder.NewError(fmt.Sprintf("Cannot use %s as conversion function from %s to %s because it returns an error", method.ID, source.T, target.T))
+	}
+	return method.Call.Clone(), nil
+}
+
 func (g *generator) BuildWithExtend(ctx *builder.MethodContext, sourceID *xtype.JenID, source, target *xtype.Type) (
 	ok bool,
 	codes []jen.Code,
@@ -224,13 +314,15 @@ func (g *generator) BuildWithExtend(ctx *builder.MethodContext, sourceID *xtype.
 		_sourceID = sourceID
 		_targetID *xtype.JenID
 		method    *builder.MethodDefinition
+		converter bool
 	)
 
 	_sourceID, _targetID, method, ok = _lookupExtend(ctx, source, target, sourceID)
 	if !ok {
+		converter = true
 		_sourceID = sourceID
 		_targetID = ctx.TargetID
-		method, ok = g._lookup(source, target, ctx.WantMethodKind)
+		method, ok = g._lookup(ctx, source, target, ctx.WantMethodKind)
 	}
 
 	if ok {
@@ -239,6 +331,14 @@ func (g *generator) BuildWithExtend(ctx *builder.MethodContext, sourceID *xtype.
 			params = append(params, jen.Id(xtype.ThisVar))
 		}
 		params = append(params, _sourceID.Code.Clone())
+		if method.Callback != nil {
+			var callback *jen.Statement
+			callback, err = g.callback(ctx, method.Callback)
+			if err != nil {
+				return
+			}
+			params = append(params, callback)
+		}
 
 		switch method.Kind {
 		case xtype.InSourceIn2Target:
@@ -247,38 +347,38 @@ func (g *generator) BuildWithExtend(ctx *builder.MethodContext, sourceID *xtype.
 		}
 
 		if method.ReturnError {
-			current := g.lookup[ctx.Signature]
-			if !current.ReturnError {
-				if current.Explicit {
-					err = builder.NewError(fmt.Sprintf("ReturnTypeMismatch: Cannot use\n\n    %s\n\nin\n\n    %s\n\nbecause no error is returned as second parameter", method.ReturnTypeOrigin, current.ID))
-					return
-				}
-				current.ReturnError = true
-				current.ReturnTypeOrigin = method.ID
-				current.Dirty = true
+			errID := jen.Id("err")
+			// the errors of converter methods name the field path starting at their source
+			if prefix := builder.FieldPathPrefix(ctx); prefix != "" && converter {
+				errID = jen.Qual("fmt", "Errorf").Call(jen.Lit(prefix+": %w"), errID)
+			}
+
+			var ret []jen.Code
+			ret, err = g.ReturnError(ctx, method.ReturnTypeOrigin, errID)
+			if err != nil {
+				return
 			}
 
 			switch method.Kind {
 			case xtype.InSourceIn2Target:
+				// methods with a target parameter name their error result err
+				assign := ":="
+				if ctx.Signature.Kind == xtype.InSourceIn2Target {
+					assign = "="
+				}
 				stmt := []jen.Code{
-					jen.List(jen.Id("err")).Op(":=").Add(
+					jen.List(jen.Id("err")).Op(assign).Add(
 						method.Call.Clone().Call(params...),
 					),
-					jen.If(jen.Id("err").Op("!=").Nil()).Block(
-						jen.Return(jen.Id("err")),
-					),
+					jen.If(jen.Id("err").Op("!=").Nil()).Block(ret...),
 				}
 				codes = stmt
 				return
 			case xtype.InSourceOutTarget:
 				name := ctx.Name(target.ID())
-				innerName := ctx.Name("errValue")
 				codes = []jen.Code{
 					jen.List(jen.Id(name), jen.Id("err")).Op(":=").Add(method.Call.Clone().Call(params...)),
-					jen.If(jen.Id("err").Op("!=").Nil()).Block(
-						jen.Var().Id(innerName).Add(ctx.TargetType.TypeAsJen()),
-						jen.Return(jen.Id(innerName), jen.Id("err")),
-					),
+					jen.If(jen.Id("err").Op("!=").Nil()).Block(ret...),
 				}
 				id = xtype.VariableID(jen.Id(name))
 
@@ -305,13 +405,41 @@ func (g *generator) Name() string {
 	return g.name
 }
 
-func (g *generator) _lookup(source, target *xtype.Type, kind xtype.MethodKind) (*builder.MethodDefinition, bool) {
+// ReturnError marks the current method as returning an error and creates the statement returning the given error.
+func (g *generator) ReturnError(ctx *builder.MethodContext, origin string, errID *jen.Statement) ([]jen.Code, *builder.Error) {
+	current := g.lookup[ctx.Signature]
+	if !current.ReturnError {
+		if current.Explicit {
+			return nil, builder.NewError(fmt.Sprintf("ReturnTypeMismatch: Cannot use\n\n    %s\n\nin\n\n    %s\n\nbecause no error is returned as second parameter", origin, current.ID))
+		}
+		current.ReturnError = true
+		current.ReturnTypeOrigin = origin
+		current.Dirty = true
+	}
+
+	if current.Kind == xtype.InSourceIn2Target {
+		return []jen.Code{jen.Return(errID)}, nil
+	}
+
+	innerName := ctx.Name("errValue")
+	return []jen.Code{
+		jen.Var().Id(innerName).Add(current.Target.TypeAsJen()),
+		jen.Return(jen.Id(innerName), errID),
+	}, nil
+}
+
+// _lookup returns the declared method for the given types, or the method generated with the settings of ctx.
+func (g *generator) _lookup(ctx *builder.MethodContext, source, target *xtype.Type, kind xtype.MethodKind) (*builder.MethodDefinition, bool) {
 	sign := xtype.Signature{
-		Source: source.T.String(),
-		Target: target.T.String(),
+		Source: xtype.TypeString(source.T),
+		Target: xtype.TypeString(target.T),
 		Kind:   kind,
 	}
 
+	if method, ok := g.lookup[sign]; ok {
+		return method, ok
+	}
+	sign.Settings = ctx.Settings()
 	method, ok := g.lookup[sign]
 	return method, ok
 }
@@ -321,6 +449,20 @@ func _lookupExtend(ctx *builder.MethodContext, source, target *xtype.Type, sourc
 	nextTargetID *xtype.JenID,
 	method *builder.MethodDefinition,
 	ok bool,
+) {
+	// generic extend methods are only used, if there is no extend method for the exact types
+	nextSourceID, nextTargetID, method, ok = lookupExtendVerbs(ctx, source, target, sourceID, false)
+	if ok {
+		return
+	}
+	return lookupExtendVerbs(ctx, source, target, sourceID, true)
+}
+
+func lookupExtendVerbs(ctx *builder.MethodContext, source, target *xtype.Type, sourceID *xtype.JenID, generic bool) (
+	nextSourceID *xtype.JenID,
+	nextTargetID *xtype.JenID,
+	method *builder.MethodDefinition,
+	ok bool,
 ) {
 	const (
 		raw byte = iota + 1
@@ -351,24 +493,24 @@ func _lookupExtend(ctx *builder.MethodContext, source, target *xtype.Type, sourc
 		}
 	}
 	for _, sVerb := range sourceVerb {
-		var sourceTy string
+		var sourceTy types.Type
 		switch sVerb {
 		case raw:
-			sourceTy = source.T.String()
+			sourceTy = source.T
 			nextSourceID = xtype.OtherID(sourceID.Code.Clone())
 		case ref:
-			sourceTy = "*" + source.T.String()
+			sourceTy = types.NewPointer(source.T)
 			nextSourceID = xtype.OtherID(jen.Op("&").Add(sourceID.Code.Clone()))
 		case deref:
-			sourceTy = source.PointerInner.T.String()
+			sourceTy = source.PointerInner.T
 			nextSourceID = xtype.OtherID(jen.Op("*").Add(sourceID.Code.Clone()))
 		}
 
 		for _, tVerb := range targetVerb {
-			var targetTy string
+			var targetTy types.Type
 			switch tVerb {
 			case raw:
-				targetTy = target.T.String()
+				targetTy = target.T
 
 				if ctx.TargetID != nil {
 					nextTargetID = xtype.OtherID(ctx.TargetID.Code.Clone())
@@ -378,23 +520,20 @@ func _lookupExtend(ctx *builder.MethodContext, source, target *xtype.Type, sourc
 					continue
 				}
 
-				targetTy = "*" + target.T.String()
+				targetTy = types.NewPointer(target.T)
 				nextTargetID = xtype.OtherID(jen.Op("&").Add(ctx.TargetID.Code.Clone()))
 			}
 
-			sign := xtype.Signature{
-				Source: sourceTy,
-				Target: targetTy,
-				Kind:   xtype.InSourceOutTarget,
-			}
-
 			for _, extends := range []map[xtype.Signature]*builder.MethodDefinition{
 				ctx.MethodExtend,
 				ctx.GlobalExtend,
 			} {
-				method, ok = extends[sign]
-				if ok {
-					return
+				// a referenced target can only be used as second parameter
+				if tVerb == raw {
+					method, ok = lookupExtendKind(extends, xtype.InSourceOutTarget, sourceTy, targetTy, generic)
+					if ok {
+						return
+					}
 				}
 
 				var needSearchInSourceIn2Target bool
@@ -407,8 +546,7 @@ func _lookupExtend(ctx *builder.MethodContext, source, target *xtype.Type, sourc
 				}
 
 				if needSearchInSourceIn2Target {
-					sign.Kind = xtype.InSourceIn2Target
-					method, ok = extends[sign]
+					method, ok = lookupExtendKind(extends, xtype.InSourceIn2Target, sourceTy, targetTy, generic)
 					if ok {
 						return
 					}
@@ -419,3 +557,47 @@ func _lookupExtend(ctx *builder.MethodContext, source, target *xtype.Type, sourc
 
 	return
 }
+
+// lookupExtendKind returns the extend method converting source to target, generic methods are only
+// instantiated if generic is set.
+func lookupExtendKind(extends map[xtype.Signature]*builder.MethodDefinition, kind xtype.MethodKind, source, target types.Type, generic bool) (*builder.MethodDefinition, bool) {
+	if generic {
+		return lookupGenericExtend(extends, kind, source, target)
+	}
+
+	method, ok := extends[xtype.Signature{Source: xtype.TypeString(source), Target: xtype.TypeString(target), Kind: kind}]
+	if !ok || method.Signature != nil {
+		return nil, false
+	}
+	return method, true
+}
+
+// supported returns an error, if the source or target type cannot be used in the generated code.
+func supported(source, target *xtype.Type) *builder.Error {
+	for _, t := range []*xtype.Type{source, target} {
+		if err := xtype.Supported(t.T); err != nil {
+			return builder.NewError(fmt.Sprintf("UnsupportedType: Cannot convert %s to %s: %s", source.T, target.T, err))
+		}
+	}
+	return nil
+}
+
+// buildTypeParam assigns values of type parameters of a generic converter, a conversion between different types
+// would depend on the instantiation of the converter.
+func buildTypeParam(sourceID *xtype.JenID, source, target *xtype.Type) ([]jen.Code, *xtype.JenID, *builder.Error) {
+	if types.Identical(source.T, target.T) {
+		return nil, sourceID, nil
+	}
+	return nil, nil, builder.NewError(fmt.Sprintf("TypeParameter: Cannot convert %s to %s because the conversion depends on the type parameters of the converter.\n\nOnly values of the same type parameter can be assigned.", source.T, target.T))
+}
+
+// matches returns true, if the rule can handle the given types.
+func matches(rule builder.Builder, ctx *builder.MethodContext, source, target *xtype.Type) bool {
+	if !rule.Matches(source, target, ctx.WantMethodKind) {
+		return false
+	}
+	if m, ok := rule.(builder.ContextMatcher); ok {
+		return m.MatchesContext(ctx, source, target)
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"go/types"
+	"sort"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/builder"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// lookupGenericExtend searches the generic extend methods for one, that can be instantiated with the source
+// and target type.
+func lookupGenericExtend(extends map[xtype.Signature]*builder.MethodDefinition, kind xtype.MethodKind, source, target types.Type) (*builder.MethodDefinition, bool) {
+	var generics []*builder.MethodDefinition
+	for _, method := range extends {
+		if method.Signature != nil && method.Kind == kind {
+			generics = append(generics, method)
+		}
+	}
+	sort.Slice(generics, func(i, j int) bool {
+		return generics[i].ID < generics[j].ID
+	})
+
+	for _, method := range generics {
+		if instance, ok := instantiate(method, source, target); ok {
+			return instance, true
+		}
+	}
+	return nil, false
+}
+
+// instantiate infers the type arguments of the generic method by unifying its source and target with the given
+// types and returns the instantiated method, that is called with explicit type arguments.
+func instantiate(method *builder.MethodDefinition, source, target types.Type) (*builder.MethodDefinition, bool) {
+	params := method.Signature.TypeParams()
+	args := make([]types.Type, params.Len())
+	if !unify(params, method.Source.T, source, args) || !unify(params, method.Target.T, target, args) {
+		return nil, false
+	}
+	for _, arg := range args {
+		if arg == nil {
+			// the type parameter isn't used in the source or target and cannot be inferred
+			return nil, false
+		}
+	}
+
+	inst, err := types.Instantiate(nil, method.Signature, args, true)
+	if err != nil {
+		// the type arguments don't satisfy the constraints
+		return nil, false
+	}
+	sig := inst.(*types.Signature)
+
+	typeArgs := make([]jen.Code, 0, len(args))
+	for _, arg := range args {
+		typeArgs = append(typeArgs, xtype.TypeOf(arg).TypeAsJen())
+	}
+
+	instance := *method
+	instance.Signature = nil
+	instance.Call = method.Call.Clone().Types(typeArgs...)
+
+	offset := 0
+	if method.SelfAsFirstParam {
+		offset = 1
+	}
+	instance.Source = xtype.TypeOf(sig.Params().At(offset).Type())
+	switch {
+	case method.Kind == xtype.InSourceIn2Target:
+		instance.Target = xtype.TypeOf(sig.Params().At(offset + 1).Type())
+	default:
+		instance.Target = xtype.TypeOf(sig.Results().At(0).Type())
+	}
+	if method.Callback != nil {
+		callback := sig.Params().At(offset + 1).Type().(*types.Signature)
+		instance.Callback = &builder.MethodDefinition{
+			Source: xtype.TypeOf(callback.Params().At(0).Type()),
+			Target: xtype.TypeOf(callback.Results().At(0).Type()),
+			Kind:   xtype.InSourceOutTarget,
+		}
+	}
+	return &instance, true
+}
+
+// unify binds the type parameters in generic to the corresponding parts of concrete, it returns false if the
+// types cannot match.
+func unify(params *types.TypeParamList, generic, concrete types.Type, args []types.Type) bool {
+	generic = types.Unalias(generic)
+	concrete = types.Unalias(concrete)
+
+	switch g := generic.(type) {
+	case *types.TypeParam:
+		if g.Index() < params.Len() && params.At(g.Index()) == g {
+			if args[g.Index()] == nil {
+				args[g.Index()] = concrete
+				return true
+			}
+			return types.Identical(args[g.Index()], concrete)
+		}
+	case *types.Pointer:
+		c, ok := concrete.(*types.Pointer)
+		return ok && unify(params, g.Elem(), c.Elem(), args)
+	case *types.Slice:
+		c, ok := concrete.(*types.Slice)
+		return ok && unify(params, g.Elem(), c.Elem(), args)
+	case *types.Array:
+		c, ok := concrete.(*types.Array)
+		return ok && g.Len() == c.Len() && unify(params, g.Elem(), c.Elem(), args)
+	case *types.Map:
+		c, ok := concrete.(*types.Map)
+		return ok && unify(params, g.Key(), c.Key(), args) && unify(params, g.Elem(), c.Elem(), args)
+	case *types.Chan:
+		c, ok := concrete.(*types.Chan)
+		return ok && g.Dir() == c.Dir() && unify(params, g.Elem(), c.Elem(), args)
+	case *types.Named:
+		c, ok := concrete.(*types.Named)
+		if !ok || g.TypeArgs().Len() == 0 || g.Origin() != c.Origin() {
+			break
+		}
+		for i := 0; i < g.TypeArgs().Len(); i++ {
+			if !unify(params, g.TypeArgs().At(i), c.TypeArgs().At(i), args) {
+				return false
+			}
+		}
+		return true
+	}
+	return types.Identical(generic, concrete)
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/pengdaCN/goverter/builder"
+	"github.com/pengdaCN/goverter/comments"
+	"github.com/pengdaCN/goverter/xtype"
+)
+
+// parseImplementations resolves the goverter:implementations declarations.
+//
+// The interface and its implementations can be one of the following:
+// 1) local scope with a name: "Circle", a pointer is declared with "*Circle"
+// 2) package with a name: "github.com/google/uuid:UUID"
+func (g *parseExtendContext) parseImplementations(
+	converterInterface types.Type,
+	converterScope *types.Scope,
+	decls map[string]*comments.Implementations,
+) (map[string]*builder.Implementations, error) {
+	names := make([]string, 0, len(decls))
+	for name := range decls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	implementations := make(map[string]*builder.Implementations, len(decls))
+	for _, name := range names {
+		decl := decls[name]
+
+		inter, err := g.lookupType(converterScope, name)
+		if err != nil {
+			return nil, err
+		}
+		interType, ok := inter.Underlying().(*types.Interface)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an interface", name)
+		}
+		if len(decl.Types) == 0 {
+			return nil, fmt.Errorf("%s has no implementations declared via goverter:implementations", name)
+		}
+
+		impl := &builder.Implementations{}
+		for _, typeName := range decl.Types {
+			t, err := g.lookupType(converterScope, typeName)
+			if err != nil {
+				return nil, err
+			}
+			if !types.Implements(t, interType) {
+				return nil, fmt.Errorf("%s does not implement %s", t, inter)
+			}
+
+			impl.Types = append(impl.Types, xtype.TypeOf(t))
+		}
+
+		if decl.Fallback != "" {
+			extend, err := g.parseExtend(converterInterface, converterScope, []string{decl.Fallback})
+			if err != nil {
+				return nil, err
+			}
+			for _, method := range extend {
+				if method.Kind != xtype.InSourceOutTarget || !types.Identical(method.Source.T, inter) {
+					return nil, fmt.Errorf("fallback %s must convert from %s", decl.Fallback, inter)
+				}
+
+				impl.Fallback = method
+			}
+		}
+
+		implementations[xtype.TypeString(inter)] = impl
+	}
+
+	return implementations, nil
+}
+
+// inheritImplementations completes the method declarations with the declarations of the converter,
+// so that f.ex. only a fallback can be declared on the method.
+func inheritImplementations(global, local map[string]*comments.Implementations) map[string]*comments.Implementations {
+	merged := make(map[string]*comments.Implementations, len(local))
+	for name, decl := range local {
+		impl := *decl
+		if g, ok := global[name]; ok {
+			if len(impl.Types) == 0 {
+				impl.Types = g.Types
+			}
+			if impl.Fallback == "" {
+				impl.Fallback = g.Fallback
+			}
+		}
+		merged[name] = &impl
+	}
+
+	return merged
+}
+
+// lookupType looks up the type with the given name either in the scope or in the package
+// prefixed with packageNameSep.
+func (g *parseExtendContext) lookupType(scope *types.Scope, name string) (types.Type, error) {
+	pointer := strings.HasPrefix(name, "*")
+	typeName := strings.TrimPrefix(name, "*")
+
+	if parts := strings.SplitN(typeName, packageNameSep, 2); len(parts) == 2 {
+		pkgs, err := g.loadPackages(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		scope = pkgs[0].Types.Scope()
+		typeName = parts[1]
+	}
+
+	obj, ok := scope.Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s does not exist in scope", name)
+	}
+
+	if pointer {
+		return types.NewPointer(obj.Type()), nil
+	}
+	return obj.Type(), nil
+}
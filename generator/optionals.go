@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pengdaCN/goverter/builder"
+	"github.com/pengdaCN/goverter/comments"
+)
+
+// parseOptionals resolves the goverter:optional declarations.
+//
+// The type is declared like with goverter:implementations, f.ex. "Option" or
+// "github.com/samber/mo:Option". The functions Some and None are looked up in the package of the type.
+func (g *parseExtendContext) parseOptionals(converterScope *types.Scope, decls []comments.Optional) (map[string]*builder.Optional, error) {
+	optionals := make(map[string]*builder.Optional, len(decls))
+	for _, decl := range decls {
+		t, err := g.lookupType(converterScope, decl.Type)
+		if err != nil {
+			return nil, err
+		}
+		named, ok := t.(*types.Named)
+		if !ok || named.TypeParams().Len() != 1 {
+			return nil, fmt.Errorf("optional %s must be a generic type with one type parameter", decl.Type)
+		}
+
+		opt := &builder.Optional{}
+		opt.Get, opt.GetMethod, err = lookupOptionalMember(named, decl.Get, nil)
+		if err != nil {
+			return nil, err
+		}
+		opt.IsSome, opt.IsSomeMethod, err = lookupOptionalMember(named, decl.IsSome, types.Typ[types.Bool])
+		if err != nil {
+			return nil, err
+		}
+
+		if decl.Some != "-" {
+			opt.Some, err = lookupOptionalFunc(named, decl.Some)
+			if err != nil {
+				return nil, err
+			}
+		} else if opt.GetMethod || opt.IsSomeMethod {
+			return nil, fmt.Errorf("optional %s can only be created without Some, if %s and %s are fields", decl.Type, decl.Get, decl.IsSome)
+		}
+		if decl.None != "" {
+			opt.None, err = lookupOptionalFunc(named, decl.None)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		optionals[builder.OptionalKey(named)] = opt
+	}
+
+	return optionals, nil
+}
+
+// lookupOptionalMember returns whether the member name of the optional type is a method, if want is set,
+// the field or the result of the method must have this type.
+func lookupOptionalMember(named *types.Named, name string, want types.Type) (string, bool, error) {
+	obj, _, _ := types.LookupFieldOrMethod(named, true, named.Obj().Pkg(), name)
+	switch member := obj.(type) {
+	case *types.Var:
+		if want != nil && !types.Identical(member.Type(), want) {
+			return "", false, fmt.Errorf("field %s of %s must have type %s", name, named.Obj().Name(), want)
+		}
+		return name, false, nil
+	case *types.Func:
+		sig := member.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			return "", false, fmt.Errorf("method %s of %s must have no parameters and one result", name, named.Obj().Name())
+		}
+		if want != nil && !types.Identical(sig.Results().At(0).Type(), want) {
+			return "", false, fmt.Errorf("method %s of %s must return %s", name, named.Obj().Name(), want)
+		}
+		return name, true, nil
+	}
+	return "", false, fmt.Errorf("%s has no field or method %s", named.Obj().Name(), name)
+}
+
+// lookupOptionalFunc looks up the generic function name in the package of the optional type.
+func lookupOptionalFunc(named *types.Named, name string) (*jen.Statement, error) {
+	pkg := named.Obj().Pkg()
+	if strings.Contains(name, packageNameSep) {
+		return nil, fmt.Errorf("function %s must be declared in the package of %s", name, named.Obj().Name())
+	}
+
+	fn, ok := pkg.Scope().Lookup(name).(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("function %s does not exist in %s", name, pkg.Path())
+	}
+	if fn.Type().(*types.Signature).TypeParams().Len() != 1 {
+		return nil, fmt.Errorf("function %s must have one type parameter", name)
+	}
+
+	return jen.Qual(pkg.Path(), name), nil
+}
@@ -14,7 +14,7 @@ func (g *parseExtendContext) loadPackages(pkgPath string) ([]*packages.Package,
 	}
 
 	packagesCfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo,
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
 		Dir:  g.workingDir,
 	}
 	pkgs, err := packages.Load(packagesCfg, pkgPath)
@@ -35,6 +35,7 @@ func ParseMethod(method *types.Func, opts ...ParseOpt) (*builder.MethodDefinitio
 		target               types.Type
 		advTarget            *xtype.Type
 		maybeErr             types.Type
+		callback             *types.Signature
 		kind                 xtype.MethodKind
 		selfAsFirstParameter bool
 		returnError          bool
@@ -58,6 +59,14 @@ func ParseMethod(method *types.Func, opts ...ParseOpt) (*builder.MethodDefinitio
 		if len(result) == 2 {
 			maybeErr = result[1].Type()
 		}
+	case len(params) == 2 && len(result) <= 2 && signature.TypeParams().Len() != 0 && isCallback(params[1].Type()):
+		kind = xtype.InSourceOutTarget
+		source = params[0].Type()
+		target = result[0].Type()
+		callback = params[1].Type().(*types.Signature)
+		if len(result) == 2 {
+			maybeErr = result[1].Type()
+		}
 	case len(params) == 2 && len(result) <= 1:
 		kind = xtype.InSourceIn2Target
 		source = params[0].Type()
@@ -92,7 +101,12 @@ func ParseMethod(method *types.Func, opts ...ParseOpt) (*builder.MethodDefinitio
 		call = jen.Id(xtype.ThisVar).Dot(method.Name())
 	}
 
-	return &builder.MethodDefinition{
+	var generic *types.Signature
+	if signature.TypeParams().Len() != 0 {
+		generic = signature
+	}
+
+	m := &builder.MethodDefinition{
 		Call:             call,
 		ID:               method.String(),
 		Name:             method.Name(),
@@ -102,7 +116,22 @@ func ParseMethod(method *types.Func, opts ...ParseOpt) (*builder.MethodDefinitio
 		Target:           advTarget,
 		ReturnError:      returnError,
 		ReturnTypeOrigin: method.FullName(),
-	}, nil
+		Signature:        generic,
+	}
+	if callback != nil {
+		m.Callback = &builder.MethodDefinition{
+			Source: xtype.TypeOf(callback.Params().At(0).Type()),
+			Target: xtype.TypeOf(callback.Results().At(0).Type()),
+			Kind:   xtype.InSourceOutTarget,
+		}
+	}
+	return m, nil
+}
+
+// isCallback returns true, if t is a conversion function like func(S) T.
+func isCallback(t types.Type) bool {
+	sig, ok := t.(*types.Signature)
+	return ok && sig.Params().Len() == 1 && sig.Results().Len() == 1
 }
 
 func tupleToVars(in *types.Tuple) []*types.Var {
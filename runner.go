@@ -26,6 +26,9 @@ type GenerateConfig struct {
 	// generated code resides, making sure goverter does not create a loop import statement from
 	// the generated code into its own package.
 	PackagePath string
+	// PreserveNilCollections converts nil slices and maps into nil instead of empty ones, unless
+	// goverter:nilCollections is declared.
+	PreserveNilCollections bool
 }
 
 // GenerateConverter generates converters.
@@ -39,10 +42,11 @@ func GenerateConverter(c GenerateConfig) ([]byte, error) {
 	}
 
 	file, err := generator.Generate(c.ScanDir, mapping, generator.Config{
-		Name:          c.PackageName,
-		PackagePath:   c.PackagePath,
-		ExtendMethods: c.ExtendMethods,
-		WorkingDir:    c.WorkingDir,
+		Name:                   c.PackageName,
+		PackagePath:            c.PackagePath,
+		ExtendMethods:          c.ExtendMethods,
+		WorkingDir:             c.WorkingDir,
+		PreserveNilCollections: c.PreserveNilCollections,
 	})
 	if err != nil {
 		return nil, err
@@ -50,10 +50,11 @@ func TestScenario(t *testing.T) {
 			err = GenerateConverterFile(
 				genFile,
 				GenerateConfig{
-					PackageName:   genPkgName,
-					PackagePath:   "github.com/jmattheis/goverter/execution/" + genPkgName,
-					ScanDir:       "github.com/jmattheis/goverter/execution",
-					ExtendMethods: scenario.Extends,
+					PackageName:            genPkgName,
+					PackagePath:            "github.com/pengdaCN/goverter/execution/" + genPkgName,
+					ScanDir:                "github.com/pengdaCN/goverter/execution",
+					ExtendMethods:          scenario.Extends,
+					PreserveNilCollections: scenario.PreserveNilCollections,
 				})
 
 			body, _ := ioutil.ReadFile(genFile)
@@ -113,6 +114,8 @@ func compile(file string) error {
 type Scenario struct {
 	Input   map[string]string `yaml:"input"`
 	Extends []string          `yaml:"extends,omitempty"`
+	// PreserveNilCollections sets the global setting.
+	PreserveNilCollections bool `yaml:"preserveNilCollections,omitempty"`
 
 	Success string `yaml:"success,omitempty"`
 	// for error cases, use either Error or ErrorStartsWith, not both
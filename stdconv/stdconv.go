@@ -0,0 +1,175 @@
+// Package stdconv contains conversions between common types of the standard library, that can be used with
+//
+//	goverter:extend std
+//
+// which is the same as
+//
+//	goverter:extend github.com/pengdaCN/goverter/stdconv:.*
+package stdconv
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TimeToString formats t as RFC3339 with nanoseconds.
+func TimeToString(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// StringToTime parses a RFC3339 timestamp.
+func StringToTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, s)
+}
+
+// TimeToUnix returns t as unix time in seconds.
+func TimeToUnix(t time.Time) int64 {
+	return t.Unix()
+}
+
+// UnixToTime returns the local time of the unix time in seconds.
+func UnixToTime(i int64) time.Time {
+	return time.Unix(i, 0)
+}
+
+// DurationToInt64 returns d in nanoseconds.
+func DurationToInt64(d time.Duration) int64 {
+	return int64(d)
+}
+
+// Int64ToDuration returns the duration of i nanoseconds.
+func Int64ToDuration(i int64) time.Duration {
+	return time.Duration(i)
+}
+
+// DurationToString formats d like "1h2m3s".
+func DurationToString(d time.Duration) string {
+	return d.String()
+}
+
+// StringToDuration parses a duration like "1h2m3s".
+func StringToDuration(s string) (time.Duration, error) {
+	return time.ParseDuration(s)
+}
+
+// URLToString returns the URL as string.
+func URLToString(u url.URL) string {
+	return u.String()
+}
+
+// StringToURL parses an URL.
+func StringToURL(s string) (url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return url.URL{}, err
+	}
+	return *u, nil
+}
+
+// URLPointerToString returns the URL as string, nil is converted to an empty string.
+func URLPointerToString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+// StringToURLPointer parses an URL.
+func StringToURLPointer(s string) (*url.URL, error) {
+	return url.Parse(s)
+}
+
+// IntToString formats i in base 10.
+func IntToString(i int) string {
+	return strconv.Itoa(i)
+}
+
+// StringToInt parses a base 10 int.
+func StringToInt(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// Int32ToString formats i in base 10.
+func Int32ToString(i int32) string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// StringToInt32 parses a base 10 int32.
+func StringToInt32(s string) (int32, error) {
+	i, err := strconv.ParseInt(s, 10, 32)
+	return int32(i), err
+}
+
+// Int64ToString formats i in base 10.
+func Int64ToString(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
+
+// StringToInt64 parses a base 10 int64.
+func StringToInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// UintToString formats i in base 10.
+func UintToString(i uint) string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+// StringToUint parses a base 10 uint.
+func StringToUint(s string) (uint, error) {
+	i, err := strconv.ParseUint(s, 10, 0)
+	return uint(i), err
+}
+
+// Uint32ToString formats i in base 10.
+func Uint32ToString(i uint32) string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+// StringToUint32 parses a base 10 uint32.
+func StringToUint32(s string) (uint32, error) {
+	i, err := strconv.ParseUint(s, 10, 32)
+	return uint32(i), err
+}
+
+// Uint64ToString formats i in base 10.
+func Uint64ToString(i uint64) string {
+	return strconv.FormatUint(i, 10)
+}
+
+// StringToUint64 parses a base 10 uint64.
+func StringToUint64(s string) (uint64, error) {
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// Float32ToString formats f with the smallest number of digits necessary.
+func Float32ToString(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
+
+// StringToFloat32 parses a float32.
+func StringToFloat32(s string) (float32, error) {
+	f, err := strconv.ParseFloat(s, 32)
+	return float32(f), err
+}
+
+// Float64ToString formats f with the smallest number of digits necessary.
+func Float64ToString(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// StringToFloat64 parses a float64.
+func StringToFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// BoolToString returns "true" or "false".
+func BoolToString(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+// StringToBool parses a bool, see strconv.ParseBool for the accepted values.
+func StringToBool(s string) (bool, error) {
+	return strconv.ParseBool(s)
+}
@@ -0,0 +1,89 @@
+package stdconv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		name string
+		v    time.Time
+		want string
+	}{
+		{
+			name: "utc",
+			v:    time.Date(2021, 5, 6, 7, 8, 9, 0, time.UTC),
+			want: "2021-05-06T07:08:09Z",
+		},
+		{
+			name: "nanoseconds",
+			v:    time.Date(2021, 5, 6, 7, 8, 9, 10, time.FixedZone("", 3600)),
+			want: "2021-05-06T07:08:09.00000001+01:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TimeToString(tt.v)
+			if got != tt.want {
+				t.Errorf("TimeToString() = %v, want %v", got, tt.want)
+			}
+			back, err := StringToTime(got)
+			if err != nil {
+				t.Fatalf("StringToTime() error = %v", err)
+			}
+			if !back.Equal(tt.v) {
+				t.Errorf("StringToTime() = %v, want %v", back, tt.v)
+			}
+		})
+	}
+}
+
+func TestStringToInt32(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       string
+		want    int32
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			v:    "-42",
+			want: -42,
+		},
+		{
+			name:    "out of range",
+			v:       "2147483648",
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			v:       "abc",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StringToInt32(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StringToInt32() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("StringToInt32() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	u, err := StringToURL("https://example.com/path?q=1")
+	if err != nil {
+		t.Fatalf("StringToURL() error = %v", err)
+	}
+	if got := URLToString(u); got != "https://example.com/path?q=1" {
+		t.Errorf("URLToString() = %v", got)
+	}
+	if got := URLPointerToString(nil); got != "" {
+		t.Errorf("URLPointerToString(nil) = %v", got)
+	}
+}
@@ -0,0 +1,62 @@
+package xtype
+
+import "go/types"
+
+// TypeString returns the string of t with all aliases resolved, conversions are keyed by it, so that a type
+// and its alias use the same converter methods.
+func TypeString(t types.Type) string {
+	return unalias(t).String()
+}
+
+// unalias resolves all aliases contained in t.
+func unalias(t types.Type) types.Type {
+	switch cast := t.(type) {
+	case *types.Alias:
+		return unalias(types.Unalias(cast))
+	case *types.Pointer:
+		return types.NewPointer(unalias(cast.Elem()))
+	case *types.Slice:
+		return types.NewSlice(unalias(cast.Elem()))
+	case *types.Array:
+		return types.NewArray(unalias(cast.Elem()), cast.Len())
+	case *types.Map:
+		return types.NewMap(unalias(cast.Key()), unalias(cast.Elem()))
+	case *types.Chan:
+		return types.NewChan(cast.Dir(), unalias(cast.Elem()))
+	case *types.Named:
+		args := cast.TypeArgs()
+		if args.Len() == 0 {
+			return cast
+		}
+		unaliased := make([]types.Type, args.Len())
+		for i := 0; i < args.Len(); i++ {
+			unaliased[i] = unalias(args.At(i))
+		}
+		inst, err := types.Instantiate(nil, cast.Origin(), unaliased, false)
+		if err != nil {
+			return cast
+		}
+		return inst
+	case *types.Signature:
+		return types.NewSignatureType(nil, nil, nil, unaliasTuple(cast.Params()), unaliasTuple(cast.Results()), cast.Variadic())
+	case *types.Struct:
+		fields := make([]*types.Var, cast.NumFields())
+		tags := make([]string, cast.NumFields())
+		for i := 0; i < cast.NumFields(); i++ {
+			field := cast.Field(i)
+			fields[i] = types.NewField(field.Pos(), field.Pkg(), field.Name(), unalias(field.Type()), field.Embedded())
+			tags[i] = cast.Tag(i)
+		}
+		return types.NewStruct(fields, tags)
+	}
+	return t
+}
+
+func unaliasTuple(tuple *types.Tuple) *types.Tuple {
+	vars := make([]*types.Var, tuple.Len())
+	for i := 0; i < tuple.Len(); i++ {
+		v := tuple.At(i)
+		vars[i] = types.NewParam(v.Pos(), v.Pkg(), v.Name(), unalias(v.Type()))
+	}
+	return types.NewTuple(vars...)
+}
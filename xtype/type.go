@@ -20,6 +20,9 @@ type Signature struct {
 	Source string
 	Target string
 	Kind   MethodKind
+	// Settings separates generated methods converting the same types with different directives, it's empty for
+	// declared methods.
+	Settings string
 }
 
 type MethodKind byte
@@ -43,6 +46,7 @@ type Type struct {
 	PointerInner  *Type
 	List          bool
 	ListFixed     bool
+	ListLen       int64
 	ListInner     *Type
 	Map           bool
 	MapType       *types.Map
@@ -50,6 +54,12 @@ type Type struct {
 	MapValue      *Type
 	Basic         bool
 	BasicType     *types.Basic
+	TypeParam     bool
+	TypeParamType *types.TypeParam
+	Func          bool
+	FuncType      *types.Signature
+	Chan          bool
+	ChanType      *types.Chan
 }
 
 // StructField holds the type of a struct field and its name.
@@ -130,6 +140,22 @@ func tagMatch(tag1, tag2 string, searchTags []string) bool {
 	return false
 }
 
+// TagName returns the name and the options of the first search tag, that is set on the struct tag.
+func TagName(tag string, searchTags []string) (string, []string) {
+	t := reflect.StructTag(tag)
+	for _, key := range searchTags {
+		v := t.Get(key)
+		if v == "" {
+			continue
+		}
+
+		parts := strings.Split(v, ",")
+		return parts[0], parts[1:]
+	}
+
+	return "", nil
+}
+
 func getTagFirstValue(v string) string {
 	const seq = ","
 	idx := strings.Index(v, seq)
@@ -246,6 +272,7 @@ func TypeOf(t types.Type) *Type {
 	case *types.Array:
 		rt.List = true
 		rt.ListFixed = true
+		rt.ListLen = value.Len()
 		rt.ListInner = TypeOf(value.Elem())
 	case *types.Named:
 		underlying := TypeOf(value.Underlying())
@@ -259,6 +286,20 @@ func TypeOf(t types.Type) *Type {
 	case *types.Interface:
 		rt.Interface = true
 		rt.InterfaceType = value
+	case *types.TypeParam:
+		rt.TypeParam = true
+		rt.TypeParamType = value
+	case *types.Signature:
+		rt.Func = true
+		rt.FuncType = value
+	case *types.Chan:
+		rt.Chan = true
+		rt.ChanType = value
+	case *types.Alias:
+		// the alias is kept for the generated code, everything else uses the aliased type
+		aliased := TypeOf(types.Unalias(value))
+		aliased.T = value
+		return aliased
 	default:
 		panic("unknown types.Type " + t.String())
 	}
@@ -295,18 +336,39 @@ func (t *Type) UnescapedID() string {
 
 func (t *Type) asID(seeNamed, escapeReserved bool) string {
 	if seeNamed && t.Named {
-		pkgName := t.NamedType.Obj().Pkg().Name()
-		name := pkgName + t.NamedType.Obj().Name()
+		name := t.NamedType.Obj().Name()
+		if pkg := t.NamedType.Obj().Pkg(); pkg != nil {
+			name = pkg.Name() + name
+		}
+		// every instantiation of a generic type gets its own id, f.ex. Page[User] -> pkgPageUser
+		args := t.NamedType.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			name += strings.Title(TypeOf(args.At(i)).asID(true, false))
+		}
 		return name
 	}
+	if t.TypeParam {
+		name := t.TypeParamType.Obj().Name()
+		if escapeReserved {
+			return "x" + name
+		}
+		return strings.ToLower(name[:1]) + name[1:]
+	}
+	if t.ListFixed {
+		return t.ListInner.asID(true, false) + "Array"
+	}
 	if t.List {
 		return t.ListInner.asID(true, false) + "List"
 	}
 	if t.Basic {
+		name := t.BasicType.String()
+		if t.BasicType.Kind() == types.UnsafePointer {
+			name = "unsafePointer"
+		}
 		if escapeReserved {
-			return "x" + t.BasicType.String()
+			return "x" + name
 		}
-		return t.BasicType.String()
+		return name
 	}
 	if t.Pointer {
 		return "p" + strings.Title(t.PointerInner.asID(true, false))
@@ -320,14 +382,118 @@ func (t *Type) asID(seeNamed, escapeReserved bool) string {
 		}
 		return "struct"
 	}
+	if t.Interface {
+		if escapeReserved {
+			return "xinterface"
+		}
+		return "interface"
+	}
+	if t.Func {
+		if escapeReserved {
+			return "xfunc"
+		}
+		return "func"
+	}
+	if t.Chan {
+		return "chan" + strings.Title(TypeOf(t.ChanType.Elem()).asID(true, false))
+	}
 	return "unknown"
 }
 
+// Supported returns an error, if the type cannot be used in the generated code.
+// It walks the type like toCode does.
+func Supported(t types.Type) error {
+	switch cast := t.(type) {
+	case *types.Named:
+		if cast.Obj().Pkg() == nil {
+			return nil
+		}
+		if args := cast.TypeArgs(); args.Len() != 0 {
+			return supportedList(args.Len(), args.At)
+		}
+		params := cast.TypeParams()
+		return supportedList(params.Len(), func(i int) types.Type { return params.At(i) })
+	case *types.TypeParam:
+		return nil
+	case *types.Map:
+		if err := Supported(cast.Key()); err != nil {
+			return err
+		}
+		return Supported(cast.Elem())
+	case *types.Slice:
+		return Supported(cast.Elem())
+	case *types.Array:
+		return Supported(cast.Elem())
+	case *types.Pointer:
+		return Supported(cast.Elem())
+	case *types.Chan:
+		return Supported(cast.Elem())
+	case *types.Struct:
+		return supportedList(cast.NumFields(), func(i int) types.Type { return cast.Field(i).Type() })
+	case *types.Signature:
+		return supportedSignature(cast)
+	case *types.Basic:
+		if cast.Info()&types.IsUntyped != 0 && cast.Kind() != types.UntypedNil {
+			return Supported(types.Default(cast))
+		}
+		if !supportedBasic(cast.Kind()) {
+			return fmt.Errorf("unsupported type %s", types.Typ[cast.Kind()])
+		}
+		return nil
+	case *types.Alias:
+		if generic, ok := types.Type(cast).(interface{ TypeArgs() *types.TypeList }); ok && cast != universeAny && generic.TypeArgs().Len() != 0 {
+			return Supported(types.Unalias(cast))
+		}
+		return nil
+	case *types.Interface:
+		if cast == universeAny || cast.Empty() {
+			return nil
+		}
+		if !cast.IsImplicit() {
+			if err := supportedList(cast.NumEmbeddeds(), cast.EmbeddedType); err != nil {
+				return err
+			}
+			return supportedList(cast.NumExplicitMethods(), func(i int) types.Type { return cast.ExplicitMethod(i).Type() })
+		}
+		if cast.NumEmbeddeds() == 1 {
+			return Supported(cast.EmbeddedType(0))
+		}
+	case *types.Union:
+		return supportedList(cast.Len(), func(i int) types.Type { return cast.Term(i).Type() })
+	}
+	return fmt.Errorf("unsupported type %s", t)
+}
+
+func supportedList(n int, at func(i int) types.Type) error {
+	for i := 0; i < n; i++ {
+		if err := Supported(at(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func supportedSignature(sig *types.Signature) error {
+	if err := supportedList(sig.Params().Len(), func(i int) types.Type { return sig.Params().At(i).Type() }); err != nil {
+		return err
+	}
+	return supportedList(sig.Results().Len(), func(i int) types.Type { return sig.Results().At(i).Type() })
+}
+
+// supportedBasic returns true, if toCodeBasic can render the kind.
+func supportedBasic(kind types.BasicKind) bool {
+	switch kind {
+	case types.String, types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+		types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64,
+		types.Bool, types.Complex128, types.Complex64, types.Float32, types.Float64,
+		types.Uintptr, types.UnsafePointer:
+		return true
+	}
+	return false
+}
+
 // TypeAsJen returns a jen representation of the type.
 func (t *Type) TypeAsJen() *jen.Statement {
-	if t.Named {
-		return toCode(t.NamedType, &jen.Statement{})
-	}
 	return toCode(t.T, &jen.Statement{})
 }
 
@@ -337,7 +503,23 @@ func toCode(t types.Type, st *jen.Statement) *jen.Statement {
 		if cast.Obj().Pkg() == nil {
 			return st.Id(cast.Obj().Name())
 		}
-		return st.Qual(cast.Obj().Pkg().Path(), cast.Obj().Name())
+		st = st.Qual(cast.Obj().Pkg().Path(), cast.Obj().Name())
+		if args := cast.TypeArgs(); args.Len() != 0 {
+			codes := make([]jen.Code, 0, args.Len())
+			for i := 0; i < args.Len(); i++ {
+				codes = append(codes, toCode(args.At(i), &jen.Statement{}))
+			}
+			st = st.Types(codes...)
+		} else if params := cast.TypeParams(); params.Len() != 0 {
+			codes := make([]jen.Code, 0, params.Len())
+			for i := 0; i < params.Len(); i++ {
+				codes = append(codes, toCode(params.At(i), &jen.Statement{}))
+			}
+			st = st.Types(codes...)
+		}
+		return st
+	case *types.TypeParam:
+		return st.Id(cast.Obj().Name())
 	case *types.Map:
 		key := toCode(cast.Key(), &jen.Statement{})
 		return toCode(cast.Elem(), st.Map(key))
@@ -347,12 +529,124 @@ func toCode(t types.Type, st *jen.Statement) *jen.Statement {
 		return toCode(cast.Elem(), st.Index(jen.Lit(int(cast.Len()))))
 	case *types.Pointer:
 		return toCode(cast.Elem(), st.Op("*"))
+	case *types.Struct:
+		fields := make([]jen.Code, 0, cast.NumFields())
+		for i := 0; i < cast.NumFields(); i++ {
+			field := cast.Field(i)
+			var code *jen.Statement
+			if field.Embedded() {
+				code = toCode(field.Type(), &jen.Statement{})
+			} else {
+				code = toCode(field.Type(), jen.Id(field.Name()))
+			}
+			if tag := cast.Tag(i); tag != "" {
+				// the tag is part of the type identity and must be kept as it is
+				code = code.Add(rawString(tag))
+			}
+			fields = append(fields, code)
+		}
+		return st.Struct(fields...)
+	case *types.Signature:
+		return toCodeSignature(cast, st.Func())
+	case *types.Chan:
+		switch cast.Dir() {
+		case types.SendOnly:
+			st = st.Chan().Op("<-")
+		case types.RecvOnly:
+			st = st.Op("<-").Chan()
+		default:
+			st = st.Chan()
+		}
+		return toCode(cast.Elem(), st)
 	case *types.Basic:
+		if cast.Info()&types.IsUntyped != 0 && cast.Kind() != types.UntypedNil {
+			// untyped constants are rendered with their default type
+			return toCode(types.Default(cast), st)
+		}
 		return toCodeBasic(cast.Kind(), st)
+	case *types.Alias:
+		if cast == universeAny {
+			return st.Any()
+		}
+		if generic, ok := types.Type(cast).(interface{ TypeArgs() *types.TypeList }); ok && generic.TypeArgs().Len() != 0 {
+			// instances of generic aliases are rendered as the aliased type
+			return toCode(types.Unalias(cast), st)
+		}
+		if cast.Obj().Pkg() == nil {
+			return st.Id(cast.Obj().Name())
+		}
+		return st.Qual(cast.Obj().Pkg().Path(), cast.Obj().Name())
+	case *types.Interface:
+		if cast == universeAny {
+			return st.Any()
+		}
+		if cast.Empty() {
+			return st.Interface()
+		}
+		if !cast.IsImplicit() {
+			methods := make([]jen.Code, 0, cast.NumEmbeddeds()+cast.NumExplicitMethods())
+			for i := 0; i < cast.NumEmbeddeds(); i++ {
+				methods = append(methods, toCode(cast.EmbeddedType(i), &jen.Statement{}))
+			}
+			for i := 0; i < cast.NumExplicitMethods(); i++ {
+				method := cast.ExplicitMethod(i)
+				methods = append(methods, toCodeSignature(method.Type().(*types.Signature), jen.Id(method.Name())))
+			}
+			return st.Interface(methods...)
+		}
+		// constraints like [T ~int | ~string]
+		if cast.IsImplicit() && cast.NumEmbeddeds() == 1 {
+			return toCode(cast.EmbeddedType(0), st)
+		}
+	case *types.Union:
+		for i := 0; i < cast.Len(); i++ {
+			if i != 0 {
+				st = st.Op("|")
+			}
+			if cast.Term(i).Tilde() {
+				st = st.Op("~")
+			}
+			st = toCode(cast.Term(i).Type(), st)
+		}
+		return st
 	}
 	panic("unsupported type " + t.String())
 }
 
+// toCodeSignature renders the parameters and results of a func type or an interface method.
+func toCodeSignature(sig *types.Signature, st *jen.Statement) *jen.Statement {
+	params := make([]jen.Code, 0, sig.Params().Len())
+	for i := 0; i < sig.Params().Len(); i++ {
+		param := sig.Params().At(i).Type()
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			params = append(params, toCode(param.(*types.Slice).Elem(), jen.Op("...")))
+			continue
+		}
+		params = append(params, toCode(param, &jen.Statement{}))
+	}
+	st = st.Params(params...)
+
+	switch sig.Results().Len() {
+	case 0:
+		return st
+	case 1:
+		return toCode(sig.Results().At(0).Type(), st)
+	}
+	results := make([]jen.Code, 0, sig.Results().Len())
+	for i := 0; i < sig.Results().Len(); i++ {
+		results = append(results, toCode(sig.Results().At(i).Type(), &jen.Statement{}))
+	}
+	return st.Params(results...)
+}
+
+// rawString renders s as raw string literal, if possible.
+func rawString(s string) *jen.Statement {
+	if strings.Contains(s, "`") {
+		return jen.Lit(s)
+	}
+	return jen.Op("`" + s + "`")
+}
+
 func toCodeBasic(t types.BasicKind, st *jen.Statement) *jen.Statement {
 	switch t {
 	case types.String:
@@ -387,8 +681,12 @@ func toCodeBasic(t types.BasicKind, st *jen.Statement) *jen.Statement {
 		return st.Float32()
 	case types.Float64:
 		return st.Float64()
+	case types.Uintptr:
+		return st.Uintptr()
+	case types.UnsafePointer:
+		return st.Qual("unsafe", "Pointer")
 	default:
-		panic(fmt.Sprintf("unsupported type %d", t))
+		panic(fmt.Sprintf("unsupported type %s", types.Typ[t]))
 	}
 }
 
@@ -1,6 +1,10 @@
 package xtype
 
-import "testing"
+import (
+	"go/token"
+	"go/types"
+	"testing"
+)
 
 func Test_getTagFirstValue(t *testing.T) {
 	type args struct {
@@ -24,3 +28,57 @@ func Test_getTagFirstValue(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeString(t *testing.T) {
+	pkg := types.NewPackage("example.com/api", "api")
+	user := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "User", nil), types.NewStruct(nil, nil), nil)
+	alias := types.NewAlias(types.NewTypeName(token.NoPos, pkg, "Member", nil), user)
+
+	tests := []struct {
+		name string
+		t    types.Type
+		want string
+	}{
+		{
+			name: "alias",
+			t:    alias,
+			want: "example.com/api.User",
+		},
+		{
+			name: "nested alias",
+			t:    types.NewMap(types.Typ[types.String], types.NewSlice(types.NewPointer(alias))),
+			want: "map[string][]*example.com/api.User",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TypeString(tt.t); got != tt.want {
+				t.Errorf("TypeString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       types.Type
+		wantErr bool
+	}{
+		{name: "uintptr", t: types.Typ[types.Uintptr]},
+		{name: "unsafe pointer", t: types.Typ[types.UnsafePointer]},
+		{name: "untyped int", t: types.Typ[types.UntypedInt]},
+		{name: "untyped nil", t: types.Typ[types.UntypedNil], wantErr: true},
+		{name: "slice of untyped nil", t: types.NewSlice(types.Typ[types.UntypedNil]), wantErr: true},
+		{name: "map of uintptr", t: types.NewMap(types.Typ[types.String], types.Typ[types.Uintptr])},
+		{name: "invalid", t: types.Typ[types.Invalid], wantErr: true},
+		{name: "func with untyped nil result", t: types.NewSignatureType(nil, nil, nil, nil, types.NewTuple(types.NewParam(0, nil, "", types.Typ[types.UntypedNil])), false), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Supported(tt.t); (err != nil) != tt.wantErr {
+				t.Errorf("Supported() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
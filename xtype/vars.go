@@ -7,6 +7,8 @@ import (
 
 var ptrElemOffset uintptr
 
+var universeAny = types.Universe.Lookup("any").Type()
+
 func init() {
 	ptrTy := reflect.TypeOf((*types.Pointer)(nil)).Elem()
 	for i := 0; i < ptrTy.NumField(); i++ {